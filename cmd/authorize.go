@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/oauth"
+)
+
+func runAuthorize(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("authorize", flag.ExitOnError)
+	provider := flags.String("provider", "", "integration to link, e.g. googledrive, dropbox or gofile")
+	clientID := flags.String("client-id", "", "OAuth client ID registered with the provider")
+	apiKey := flags.String("api-key", os.Getenv("TORBOX_INTEGRATION_API_KEY"), "API key for providers that use one")
+	listen := flags.String("listen", oauth.DefaultListenAddr, "loopback address for the OAuth callback, must match the registered redirect URI")
+	flags.Parse(args)
+
+	info, ok := constants.IntegrationProvider(*provider).Info()
+	if !ok {
+		return fmt.Errorf("unknown provider %q", *provider)
+	}
+
+	if info.Auth == constants.IntegrationAuthAPIKey {
+		if *apiKey == "" {
+			return fmt.Errorf("%s needs -api-key", info.Name)
+		}
+
+		return client.General.Authorize(info.Provider, *apiKey)
+	}
+
+	if *clientID == "" {
+		return errors.New("-client-id is required for OAuth providers")
+	}
+
+	helper, err := oauth.New(client.General, info.Provider, *clientID,
+		oauth.WithListenAddr(*listen),
+		oauth.WithBrowser(func(authURL string) error {
+			fmt.Printf("Open this URL to authorize %s:\n\n%s\n\n", info.Name, authURL)
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = helper.Authorize(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s authorized\n", info.Name)
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/backup"
+)
+
+func runBackup(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := flags.String("o", "torbox-backup.json", "archive file to write")
+	flags.Parse(args)
+
+	archive, err := backup.Create(client.General)
+	if err != nil {
+		return err
+	}
+
+	err = archive.WriteFile(*output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %s: %d torrents, %d usenet, %d web downloads, %d rss feeds, %d skipped\n",
+		*output,
+		len(archive.Torrents),
+		len(archive.Usenet),
+		len(archive.WebDownloads),
+		len(archive.RSSFeeds),
+		len(archive.Skipped),
+	)
+
+	for _, skipped := range archive.Skipped {
+		fmt.Printf("  skipped %s %d %s: %s\n", skipped.Kind, skipped.ID, skipped.Name, skipped.Reason)
+	}
+
+	return nil
+}
+
+func runRestore(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	input := flags.String("i", "torbox-backup.json", "archive file to restore")
+	progress := flags.String("progress", "torbox-restore-progress.json", "file recording restored items for resuming")
+	delay := flags.Duration("delay", 0, "wait between adds")
+	asQueued := flags.Bool("queued", false, "add items to the queue instead of starting them")
+	flags.Parse(args)
+
+	archive, err := backup.ReadFile(*input)
+	if err != nil {
+		return err
+	}
+
+	restorer := backup.NewRestorer(client.General,
+		backup.WithProgressFile(*progress),
+		backup.WithDelay(*delay),
+		backup.WithAsQueued(*asQueued),
+	)
+
+	report, err := restorer.Restore(ctx, archive)
+	if report != nil {
+		fmt.Printf("restored %d, skipped %d duplicates, %d failed\n", len(report.Restored), len(report.Duplicates), len(report.Failed))
+	}
+
+	return err
+}
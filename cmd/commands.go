@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+)
+
+type command func(ctx context.Context, client *torbox.Client, args []string) error
+
+var commands = map[string]command{
+	"sync":      runSync,
+	"janitor":   runJanitor,
+	"schedule":  runSchedule,
+	"forward":   runForward,
+	"authorize": runAuthorize,
+	"rss-test":  runRSSTest,
+	"backup":    runBackup,
+	"restore":   runRestore,
+}
+
+func runCommand(ctx context.Context, client *torbox.Client, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	return cmd(ctx, client, args)
+}
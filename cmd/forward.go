@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/notify"
+)
+
+func runForward(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("forward", flag.ExitOnError)
+	webhook := flags.String("webhook", "", "generic webhook URL")
+	webhookSecret := flags.String("webhook-secret", os.Getenv("TORBOX_WEBHOOK_SECRET"), "HMAC secret for -webhook")
+	discord := flags.String("discord", "", "Discord webhook URL")
+	ntfyServer := flags.String("ntfy-server", "https://ntfy.sh", "ntfy server")
+	ntfyTopic := flags.String("ntfy-topic", "", "ntfy topic to publish to")
+	smtpAddr := flags.String("smtp-addr", "", "SMTP server host:port")
+	smtpFrom := flags.String("smtp-from", "", "sender address for -smtp-addr")
+	smtpTo := flags.String("smtp-to", "", "comma separated recipients for -smtp-addr")
+	smtpUser := flags.String("smtp-user", "", "SMTP username, the password is read from TORBOX_SMTP_PASSWORD")
+	titleTemplate := flags.String("title", notify.DefaultTitleTemplate, "message title template")
+	bodyTemplate := flags.String("body", notify.DefaultBodyTemplate, "message body template")
+	stateFile := flags.String("state-file", "torbox-notifications.json", "file holding the notification cursor")
+	deadLetter := flags.String("dead-letter", "torbox-dead-letters.jsonl", "file undeliverable messages are appended to")
+	interval := flags.Duration("interval", notify.DefaultInterval, "how often to poll notifications and downloads")
+	downloads := flags.Bool("downloads", false, "also forward finished downloads seen by polling, for accounts with download notifications turned off")
+	flags.Parse(args)
+
+	var sinks []notify.Sink
+	if *webhook != "" {
+		sinks = append(sinks, notify.NewWebhookSink(*webhook, *webhookSecret))
+	}
+
+	if *discord != "" {
+		sinks = append(sinks, notify.NewDiscordSink(*discord))
+	}
+
+	if *ntfyTopic != "" {
+		sinks = append(sinks, notify.NewNtfySink(*ntfyServer, *ntfyTopic))
+	}
+
+	if *smtpAddr != "" {
+		var auth smtp.Auth
+		if *smtpUser != "" {
+			host, _, err := net.SplitHostPort(*smtpAddr)
+			if err != nil {
+				return err
+			}
+
+			auth = smtp.PlainAuth("", *smtpUser, os.Getenv("TORBOX_SMTP_PASSWORD"), host)
+		}
+
+		sinks = append(sinks, notify.NewSMTPSink(*smtpAddr, *smtpFrom, strings.Split(*smtpTo, ","), auth))
+	}
+
+	if len(sinks) == 0 {
+		return errors.New("no sinks configured, set at least one of -webhook, -discord, -ntfy-topic or -smtp-addr")
+	}
+
+	forwarder, err := notify.NewForwarder(client.General, sinks,
+		notify.WithTemplates(*titleTemplate, *bodyTemplate),
+		notify.WithDeadLetterFile(*deadLetter),
+		notify.WithDownloadEvents(*downloads, *interval),
+		notify.WithStreamOptions(
+			notify.WithStateFile(*stateFile),
+			notify.WithInterval(*interval),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return forwarder.Run(ctx)
+}
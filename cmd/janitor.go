@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/janitor"
+)
+
+func runJanitor(ctx context.Context, client *torbox.Client, args []string) error {
+	policy := janitor.DefaultPolicy()
+
+	flags := flag.NewFlagSet("janitor", flag.ExitOnError)
+	flags.DurationVar(&policy.ReannounceAfter, "reannounce-after", policy.ReannounceAfter, "reannounce torrents stuck for this long (0 disables)")
+	flags.DurationVar(&policy.PauseAfter, "pause-after", policy.PauseAfter, "pause torrents stuck for this long (0 disables)")
+	flags.DurationVar(&policy.DeleteAfter, "delete-after", policy.DeleteAfter, "delete torrents stuck for this long (0 disables)")
+	flags.Int64Var(&policy.MinSeeds, "min-seeds", policy.MinSeeds, "leave torrents with at least this many seeds alone")
+	flags.Float64Var(&policy.MinAvailability, "min-availability", policy.MinAvailability, "leave torrents with at least this availability alone")
+	stateFile := flags.String("state-file", "torbox-janitor.json", "file tracking how long torrents have been stuck")
+	dryRun := flags.Bool("dry-run", false, "report actions without applying them")
+	flags.Parse(args)
+
+	j := janitor.New(client.General, policy,
+		janitor.WithStateFile(*stateFile),
+		janitor.WithDryRun(*dryRun),
+	)
+
+	report, err := j.Run()
+	if report != nil {
+		report.Print(os.Stdout)
+	}
+
+	return err
+}
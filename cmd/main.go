@@ -29,6 +29,15 @@ func main() {
 		panic(err)
 	}
 
+	if len(os.Args) > 1 {
+		err = runCommand(ctx, client, os.Args[1], os.Args[2:])
+		if err != nil {
+			log.Fatal().Err(err).Str("command", os.Args[1]).Msg("command failed")
+		}
+
+		return
+	}
+
 	activeTorrents, err := client.General.GetActiveTorrents()
 	if err != nil {
 		log.Error().Err(err).Msg("failed to get download URL")
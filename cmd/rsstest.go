@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dylanmazurek/go-torbox/pkg/rss"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func runRSSTest(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("rss-test", flag.ExitOnError)
+	feedLocation := flags.String("feed", "", "feed URL or saved XML file")
+	rssId := flags.Int64("rss-id", 0, "use the rules, and URL unless -feed is set, of an existing TorBox feed")
+	doRegex := flags.String("do", "", "do_regex, titles must match it")
+	dontRegex := flags.String("dont", "", "dont_regex, titles must not match it")
+	dontOlderThan := flags.Int("older-than", 0, "dont_older_than, skip items older than this many days")
+	flags.Parse(args)
+
+	var rules models.RSSRules
+	location := *feedLocation
+
+	if *rssId != 0 {
+		feeds, err := client.General.GetRSSFeeds()
+		if err != nil {
+			return err
+		}
+
+		found := false
+		for _, feed := range feeds {
+			if feed.ID == *rssId {
+				rules = feed.RSSRules
+				if location == "" {
+					location = feed.URL
+				}
+
+				found = true
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("rss feed %d: %w", *rssId, torboxerrors.ErrDownloadNotFound)
+		}
+	}
+
+	if location == "" {
+		flags.Usage()
+		return fmt.Errorf("-feed or -rss-id is required")
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "do":
+			rules.DoRegex = doRegex
+		case "dont":
+			rules.DontRegex = dontRegex
+		case "older-than":
+			rules.DontOlderThan = dontOlderThan
+		}
+	})
+
+	report, err := rss.TestRules(ctx, location, rules)
+	if err != nil {
+		return err
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
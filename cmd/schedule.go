@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/scheduler"
+)
+
+func runSchedule(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("schedule", flag.ExitOnError)
+	stateFile := flags.String("state-file", "torbox-scheduler.json", "file holding the queue and priorities")
+	interval := flags.Duration("interval", scheduler.DefaultInterval, "how often to check for free slots")
+	id := flags.Int64("id", 0, "queued item to prioritise, used with -priority")
+	priority := flags.Int("priority", 0, "priority for -id, higher starts first")
+	once := flags.Bool("once", false, "fill free slots once and exit")
+	flags.Parse(args)
+
+	s := scheduler.New(client.General,
+		scheduler.WithStateFile(*stateFile),
+		scheduler.WithInterval(*interval),
+	)
+
+	if *id != 0 {
+		return s.SetPriority(*id, *priority)
+	}
+
+	if *once {
+		started, err := s.RunOnce()
+		for _, entry := range started {
+			fmt.Printf("started %s %d %s (priority %d)\n", entry.Kind, entry.QueuedID, entry.Name, entry.Priority)
+		}
+
+		return err
+	}
+
+	return s.Run(ctx)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/mirror"
+)
+
+func runSync(ctx context.Context, client *torbox.Client, args []string) error {
+	flags := flag.NewFlagSet("sync", flag.ExitOnError)
+	kind := flags.String("kind", "torrent", "item kind: torrent, usenet or webdl")
+	id := flags.Int64("id", 0, "id of the finished item to sync")
+	dest := flags.String("dest", "", "local target directory")
+	deleteExtra := flags.Bool("delete", false, "delete files an earlier sync wrote that are no longer present remotely")
+	checksum := flags.Bool("checksum", false, "compare md5 hashes instead of sizes where available")
+	dryRun := flags.Bool("dry-run", false, "print the plan without applying it")
+	flags.Parse(args)
+
+	if *id == 0 || *dest == "" {
+		flags.Usage()
+		return fmt.Errorf("both -id and -dest are required")
+	}
+
+	syncer := mirror.New(client.General)
+	opts := mirror.Options{
+		Delete:   *deleteExtra,
+		Checksum: *checksum,
+	}
+
+	var plan *mirror.Plan
+	switch *kind {
+	case "torrent":
+		torrents, err := client.General.GetActiveTorrents()
+		if err != nil {
+			return err
+		}
+
+		for _, t := range torrents {
+			if t.ID == *id {
+				plan, err = syncer.PlanTorrent(t, *dest, opts)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case "usenet":
+		usenetList, err := client.General.GetUsenetList()
+		if err != nil {
+			return err
+		}
+
+		for _, u := range usenetList {
+			if u.ID == *id {
+				plan, err = syncer.PlanUsenet(u, *dest, opts)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case "webdl":
+		webList, err := client.General.GetWebDownloadList()
+		if err != nil {
+			return err
+		}
+
+		for _, w := range webList {
+			if w.ID == *id {
+				plan, err = syncer.PlanWebDownload(w, *dest, opts)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %q", *kind)
+	}
+
+	if plan == nil {
+		return fmt.Errorf("%s %d: %w", *kind, *id, torboxerrors.ErrDownloadNotFound)
+	}
+
+	plan.Print(os.Stdout)
+
+	if *dryRun {
+		return nil
+	}
+
+	return syncer.Apply(ctx, plan)
+}
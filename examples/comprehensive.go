@@ -142,7 +142,7 @@ func main() {
 	if err != nil {
 		log.Error().Err(err).Msg("failed to check cache")
 	} else {
-		if cacheInfo.Cached {
+		if cacheInfo != nil {
 			fmt.Printf("Cached! Name: %s, Size: %d bytes\n", cacheInfo.Name, cacheInfo.Size)
 		} else {
 			fmt.Println("Not cached")
@@ -1,7 +1,10 @@
 package crypto
 
 import (
+	"crypto/hmac"
+	"crypto/md5"
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
 )
 
@@ -11,3 +14,17 @@ func ToSHA1(data []byte) string {
 
 	return fmt.Sprintf("%x", hash.Sum(nil))
 }
+
+func ToMD5(data []byte) string {
+	hash := md5.New()
+	hash.Write(data)
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+func ToHMACSHA256(key []byte, data []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+
+	return fmt.Sprintf("%x", mac.Sum(nil))
+}
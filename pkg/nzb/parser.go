@@ -0,0 +1,212 @@
+package nzb
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/internal/crypto"
+)
+
+func Parse(reader io.Reader) (*NZB, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charsetReader
+
+	err = decoder.Decode(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nzb: %w", err)
+	}
+
+	if len(metadata.Files) == 0 {
+		return nil, errors.New("invalid nzb: no files")
+	}
+
+	meta := make(map[string][]string)
+	for _, m := range metadata.Meta {
+		metaType := strings.ToLower(strings.TrimSpace(m.Type))
+		meta[metaType] = append(meta[metaType], strings.TrimSpace(m.Value))
+	}
+
+	files := make([]*File, 0, len(metadata.Files))
+	for i, f := range metadata.Files {
+		file, err := parseFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nzb: file %d: %w", i+1, err)
+		}
+
+		files = append(files, file)
+	}
+
+	return &NZB{
+		Meta:  meta,
+		Files: files,
+		Hash:  crypto.ToMD5(data),
+	}, nil
+}
+
+func ParseFromFile(path string) (*NZB, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+func parseFile(f FileMetadata) (*File, error) {
+	if len(f.Segments) == 0 {
+		return nil, errors.New("no segments")
+	}
+
+	segments := make([]Segment, 0, len(f.Segments))
+	seen := make(map[int]bool, len(f.Segments))
+
+	var size int64
+	for _, s := range f.Segments {
+		messageID := strings.Trim(strings.TrimSpace(s.MessageID), "<>")
+		if messageID == "" {
+			return nil, fmt.Errorf("segment %d has no message id", s.Number)
+		}
+
+		if s.Number < 1 {
+			return nil, fmt.Errorf("segment number %d out of range", s.Number)
+		}
+
+		if s.Bytes < 0 {
+			return nil, fmt.Errorf("segment %d has negative size", s.Number)
+		}
+
+		if seen[s.Number] {
+			return nil, fmt.Errorf("duplicate segment %d", s.Number)
+		}
+		seen[s.Number] = true
+
+		segments = append(segments, Segment{
+			Number:    s.Number,
+			Bytes:     s.Bytes,
+			MessageID: messageID,
+		})
+
+		size += s.Bytes
+	}
+
+	slices.SortFunc(segments, func(a, b Segment) int {
+		return a.Number - b.Number
+	})
+
+	groups := make([]string, 0, len(f.Groups))
+	for _, g := range f.Groups {
+		g = strings.TrimSpace(g)
+		if g != "" {
+			groups = append(groups, g)
+		}
+	}
+
+	file := &File{
+		Name:     fileName(f.Subject),
+		Subject:  f.Subject,
+		Poster:   f.Poster,
+		Groups:   groups,
+		Segments: segments,
+		Size:     size,
+	}
+
+	if f.Date > 0 {
+		file.Date = time.Unix(f.Date, 0).UTC()
+	}
+
+	return file, nil
+}
+
+// fileName extracts the quoted name from subjects such as
+// `[1/3] - "example.part1.rar" yEnc (1/50)`.
+func fileName(subject string) string {
+	start := strings.Index(subject, `"`)
+	if start >= 0 {
+		end := strings.Index(subject[start+1:], `"`)
+		if end > 0 {
+			return subject[start+1 : start+1+end]
+		}
+	}
+
+	return strings.TrimSpace(subject)
+}
+
+// Size is the total encoded size of every file.
+func (n *NZB) Size() int64 {
+	var size int64
+	for _, f := range n.Files {
+		size += f.Size
+	}
+
+	return size
+}
+
+// Title returns the title meta value, if present.
+func (n *NZB) Title() string {
+	return n.firstMeta("title")
+}
+
+// Password returns the archive password meta value, if present.
+func (n *NZB) Password() string {
+	return n.firstMeta("password")
+}
+
+// Groups lists every newsgroup referenced by the files, without duplicates.
+func (n *NZB) Groups() []string {
+	var groups []string
+	for _, f := range n.Files {
+		for _, g := range f.Groups {
+			if !slices.Contains(groups, g) {
+				groups = append(groups, g)
+			}
+		}
+	}
+
+	return groups
+}
+
+func (n *NZB) firstMeta(metaType string) string {
+	values := n.Meta[metaType]
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// charsetReader handles the encodings NZB files are written in besides UTF-8.
+// Latin-1 bytes map directly onto the first 256 code points.
+func charsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+
+		var converted strings.Builder
+		for _, b := range data {
+			converted.WriteRune(rune(b))
+		}
+
+		return strings.NewReader(converted.String()), nil
+	default:
+		return nil, fmt.Errorf("unsupported charset %q", label)
+	}
+}
@@ -0,0 +1,173 @@
+package nzb
+
+import (
+	"strings"
+	"testing"
+)
+
+const validNZB = `<?xml version="1.0" encoding="iso-8859-1" ?>
+<!DOCTYPE nzb PUBLIC "-//newzBin//DTD NZB 1.1//EN" "http://www.newzbin.com/DTD/nzb/nzb-1.1.dtd">
+<nzb xmlns="http://www.newzbin.com/DTD/2003/nzb">
+  <head>
+    <meta type="title">Example Release</meta>
+    <meta type="password">secret</meta>
+  </head>
+  <file poster="poster@example.com" date="1700000000" subject="[1/2] - &quot;example.part1.rar&quot; yEnc (1/2)">
+    <groups>
+      <group>alt.binaries.example</group>
+    </groups>
+    <segments>
+      <segment bytes="200" number="2">part2of2@example.com</segment>
+      <segment bytes="100" number="1">&lt;part1of2@example.com&gt;</segment>
+    </segments>
+  </file>
+  <file poster="poster@example.com" date="1700000000" subject="example.par2">
+    <groups>
+      <group>alt.binaries.example</group>
+      <group>alt.binaries.other</group>
+    </groups>
+    <segments>
+      <segment bytes="50" number="1">par@example.com</segment>
+    </segments>
+  </file>
+</nzb>`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:  "valid nzb",
+			input: validNZB,
+		},
+		{
+			name:    "not xml",
+			input:   "this is not an nzb",
+			wantErr: true,
+		},
+		{
+			name:    "wrong root element",
+			input:   `<rss><channel></channel></rss>`,
+			wantErr: true,
+		},
+		{
+			name:        "no files",
+			input:       `<nzb><head></head></nzb>`,
+			wantErr:     true,
+			expectedErr: "invalid nzb: no files",
+		},
+		{
+			name:        "file without segments",
+			input:       `<nzb><file subject="a"><segments></segments></file></nzb>`,
+			wantErr:     true,
+			expectedErr: "invalid nzb: file 1: no segments",
+		},
+		{
+			name:        "duplicate segment",
+			input:       `<nzb><file subject="a"><segments><segment bytes="1" number="1">a@b</segment><segment bytes="1" number="1">c@d</segment></segments></file></nzb>`,
+			wantErr:     true,
+			expectedErr: "invalid nzb: file 1: duplicate segment 1",
+		},
+		{
+			name:        "segment without message id",
+			input:       `<nzb><file subject="a"><segments><segment bytes="1" number="1"> </segment></segments></file></nzb>`,
+			wantErr:     true,
+			expectedErr: "invalid nzb: file 1: segment 1 has no message id",
+		},
+		{
+			name:    "truncated",
+			input:   validNZB[:len(validNZB)/2],
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(strings.NewReader(tt.input))
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse() expected error but got none")
+				}
+				if tt.expectedErr != "" && err.Error() != tt.expectedErr {
+					t.Errorf("Parse() error = %v, expected %v", err.Error(), tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse() unexpected error = %v", err)
+			}
+
+			if result.Title() != "Example Release" {
+				t.Errorf("Title() = %v, expected %v", result.Title(), "Example Release")
+			}
+
+			if result.Password() != "secret" {
+				t.Errorf("Password() = %v, expected %v", result.Password(), "secret")
+			}
+
+			if result.Size() != 350 {
+				t.Errorf("Size() = %v, expected %v", result.Size(), 350)
+			}
+
+			if len(result.Groups()) != 2 {
+				t.Errorf("Groups() = %v, expected 2 groups", result.Groups())
+			}
+
+			first := result.Files[0]
+			if first.Name != "example.part1.rar" {
+				t.Errorf("Files[0].Name = %v, expected %v", first.Name, "example.part1.rar")
+			}
+
+			if first.Segments[0].Number != 1 || first.Segments[0].MessageID != "part1of2@example.com" {
+				t.Errorf("Files[0].Segments[0] = %+v, expected segment 1 without brackets", first.Segments[0])
+			}
+
+			if len(result.Hash) != 32 {
+				t.Errorf("Hash = %v, expected an md5 hex digest", result.Hash)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add(validNZB)
+	f.Add(`<nzb><file subject="a"><segments><segment bytes="1" number="1">a@b</segment></segments></file></nzb>`)
+	f.Add(`<nzb><file subject=""><segments><segment bytes="-1" number="0"></segment></segments></file></nzb>`)
+	f.Add(`<?xml version="1.0" encoding="koi8-r"?><nzb></nzb>`)
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		result, err := Parse(strings.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		var size int64
+		for _, file := range result.Files {
+			if len(file.Segments) == 0 {
+				t.Fatalf("parsed file %q without segments", file.Subject)
+			}
+
+			for i, segment := range file.Segments {
+				if segment.Number < 1 || segment.Bytes < 0 || segment.MessageID == "" {
+					t.Fatalf("invalid segment %+v accepted", segment)
+				}
+
+				if i > 0 && file.Segments[i-1].Number >= segment.Number {
+					t.Fatalf("segments not sorted: %+v", file.Segments)
+				}
+			}
+
+			size += file.Size
+		}
+
+		if size != result.Size() {
+			t.Fatalf("Size() = %d, files sum to %d", result.Size(), size)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package nzb
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type MetaMetadata struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type SegmentMetadata struct {
+	Bytes     int64  `xml:"bytes,attr"`
+	Number    int    `xml:"number,attr"`
+	MessageID string `xml:",chardata"`
+}
+
+type FileMetadata struct {
+	Poster   string            `xml:"poster,attr"`
+	Date     int64             `xml:"date,attr"`
+	Subject  string            `xml:"subject,attr"`
+	Groups   []string          `xml:"groups>group"`
+	Segments []SegmentMetadata `xml:"segments>segment"`
+}
+
+type Metadata struct {
+	XMLName xml.Name       `xml:"nzb"`
+	Meta    []MetaMetadata `xml:"head>meta"`
+	Files   []FileMetadata `xml:"file"`
+}
+
+type Segment struct {
+	// Segment position within the file, starting at 1
+	Number int
+
+	// Encoded size in bytes
+	Bytes int64
+
+	// Article message ID without angle brackets
+	MessageID string
+}
+
+type File struct {
+	// File name taken from the quoted part of the subject, or the subject
+	// itself when it has no quotes
+	Name string
+
+	Subject string
+	Poster  string
+	Date    time.Time
+	Groups  []string
+
+	// Segments sorted by number
+	Segments []Segment
+
+	// Sum of segment sizes
+	Size int64
+}
+
+type NZB struct {
+	// Meta holds the head meta values by type, such as title, password and tag
+	Meta map[string][]string
+
+	Files []*File
+
+	// MD5 of the raw NZB, as used by TorBox to identify usenet downloads
+	Hash string
+}
@@ -0,0 +1,184 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// feedClient fetches feeds for TestRules, the timeout stops a stalled server
+// from hanging the dry run.
+var feedClient = &http.Client{Timeout: 30 * time.Second}
+
+// RulesFromTorBox converts the rules of an AddRSSRequest or ModifyRSSRequest
+// so they can be evaluated locally.
+func RulesFromTorBox(r models.RSSRules) Rules {
+	var rules Rules
+
+	if r.DoRegex != nil && *r.DoRegex != "" {
+		rules.Include = []string{*r.DoRegex}
+	}
+
+	if r.DontRegex != nil && *r.DontRegex != "" {
+		rules.Exclude = []string{*r.DontRegex}
+	}
+
+	if r.DontOlderThan != nil && *r.DontOlderThan > 0 {
+		rules.MaxAge = time.Duration(*r.DontOlderThan) * 24 * time.Hour
+	}
+
+	return rules
+}
+
+// LoadFeed reads a feed from an http(s) URL or from a saved file.
+func LoadFeed(ctx context.Context, location string) (*Feed, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		file, err := os.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return Parse(file)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := feedClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("fetching %s: %s", location, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+// TestRules loads the feed at location, a URL or saved file, and reports
+// which items TorBox rules would add and why. Patterns RE2 cannot compile are
+// listed in the report's Unsupported field instead of failing, since the
+// server's regex engine may accept them.
+func TestRules(ctx context.Context, location string, rules models.RSSRules) (*DryRunReport, error) {
+	err := rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := LoadFeed(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, unsupported := RulesFromTorBox(rules).CompileSupported()
+
+	report := evaluate(feed, matcher, time.Now())
+	report.Unsupported = unsupported
+
+	return report, nil
+}
+
+type DryRunEntry struct {
+	Item     Item
+	Decision Decision
+}
+
+// DryRunReport lists every item of a feed with the decision the rules made.
+type DryRunReport struct {
+	Feed    string
+	Entries []DryRunEntry
+
+	// Unsupported holds patterns RE2 could not compile. They were skipped, so
+	// the server's decisions may differ from the entries.
+	Unsupported []string
+}
+
+// DryRun evaluates rules against every item in feed without adding anything.
+func DryRun(feed *Feed, rules Rules, now time.Time) (*DryRunReport, error) {
+	matcher, err := rules.Compile()
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluate(feed, matcher, now), nil
+}
+
+func evaluate(feed *Feed, matcher *Matcher, now time.Time) *DryRunReport {
+	report := &DryRunReport{
+		Feed: feed.Title,
+	}
+
+	for _, item := range feed.Items {
+		report.Entries = append(report.Entries, DryRunEntry{
+			Item:     item,
+			Decision: matcher.Evaluate(item, now),
+		})
+	}
+
+	return report
+}
+
+func (r *DryRunReport) Matches() []DryRunEntry {
+	var matches []DryRunEntry
+	for _, entry := range r.Entries {
+		if entry.Decision.Match {
+			matches = append(matches, entry)
+		}
+	}
+
+	return matches
+}
+
+// Print renders the report as a table, the text each pattern matched is
+// wrapped in brackets.
+func (r *DryRunReport) Print(w io.Writer) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetTitle(r.Feed)
+	t.AppendHeader(table.Row{"Result", "Title", "Size", "Published", "Reason"})
+
+	for _, entry := range r.Entries {
+		result := "skip"
+		if entry.Decision.Match {
+			result = "match"
+		}
+
+		published := ""
+		if !entry.Item.Published.IsZero() {
+			published = entry.Item.Published.Format(time.DateTime)
+		}
+
+		t.AppendRow(table.Row{
+			result,
+			entry.Decision.Highlight(entry.Item.Title, "[", "]"),
+			entry.Item.Size,
+			published,
+			entry.Decision.Reason,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", fmt.Sprintf("%d items, %d match", len(r.Entries), len(r.Matches()))})
+	t.Render()
+
+	for _, pattern := range r.Unsupported {
+		fmt.Fprintf(w, "pattern %q is unsupported by RE2 and was skipped, server may differ\n", pattern)
+	}
+}
@@ -0,0 +1,123 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestTestRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	err := os.WriteFile(path, []byte(fmt.Sprintf(torznabFeed, "http://localhost")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doRegex := `S01E0[12]`
+	dontRegex := `CAM`
+	report, err := TestRules(context.Background(), path, models.RSSRules{
+		DoRegex:   &doRegex,
+		DontRegex: &dontRegex,
+	})
+	if err != nil {
+		t.Fatalf("TestRules() error = %v", err)
+	}
+
+	expected := []struct {
+		match bool
+		title string
+	}{
+		{match: true, title: "Show.[S01E01].1080p.WEB-DL"},
+		{match: true, title: "Show.[S01E02].1080p.WEB-DL"},
+		{match: false, title: "Show.S01E03.720p.[CAM]"},
+		{match: false, title: "Show.S01E04.1080p.WEB-DL"},
+	}
+
+	if len(report.Entries) != len(expected) {
+		t.Fatalf("TestRules() returned %d entries, expected %d", len(report.Entries), len(expected))
+	}
+
+	for i, entry := range report.Entries {
+		title := entry.Decision.Highlight(entry.Item.Title, "[", "]")
+		if entry.Decision.Match != expected[i].match || title != expected[i].title {
+			t.Errorf("entry %d = %v %q (%s), expected %v %q", i, entry.Decision.Match, title, entry.Decision.Reason, expected[i].match, expected[i].title)
+		}
+	}
+
+	var out bytes.Buffer
+	report.Print(&out)
+	if !strings.Contains(out.String(), "4 ITEMS, 2 MATCH") {
+		t.Errorf("Print() output missing summary:\n%s", out.String())
+	}
+}
+
+func TestTestRulesUnsupportedRegex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	err := os.WriteFile(path, []byte(fmt.Sprintf(torznabFeed, "http://localhost")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		doRegex     string
+		dontRegex   string
+		unsupported []string
+		matches     int
+	}{
+		{
+			name:      "all patterns supported",
+			doRegex:   `S01E0[12]`,
+			dontRegex: `CAM`,
+			matches:   2,
+		},
+		{
+			name:        "lookbehind is skipped",
+			doRegex:     `S01E0[12]`,
+			dontRegex:   `(?<!\.)CAM`,
+			unsupported: []string{`(?<!\.)CAM`},
+			matches:     2,
+		},
+		{
+			name:        "backreference is skipped",
+			doRegex:     `(S01)E0\1`,
+			unsupported: []string{`(S01)E0\1`},
+			matches:     4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := models.RSSRules{DoRegex: &tt.doRegex}
+			if tt.dontRegex != "" {
+				rules.DontRegex = &tt.dontRegex
+			}
+
+			report, err := TestRules(context.Background(), path, rules)
+			if err != nil {
+				t.Fatalf("TestRules() error = %v", err)
+			}
+
+			if !slices.Equal(report.Unsupported, tt.unsupported) {
+				t.Errorf("TestRules() unsupported = %q, expected %q", report.Unsupported, tt.unsupported)
+			}
+
+			if len(report.Matches()) != tt.matches {
+				t.Errorf("TestRules() matched %d items, expected %d", len(report.Matches()), tt.matches)
+			}
+
+			var out bytes.Buffer
+			report.Print(&out)
+			if len(tt.unsupported) > 0 && !strings.Contains(out.String(), "server may differ") {
+				t.Errorf("Print() output missing unsupported note:\n%s", out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package rss
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/dylanmazurek/go-torbox/pkg/torrent"
+	"github.com/rs/zerolog/log"
+)
+
+// maxFetchSize bounds feed and .torrent enclosure downloads.
+const maxFetchSize = 10 << 20
+
+// Adder adds matched items. *general.GeneralService implements it, so adds
+// go through the client's rate limiter.
+type Adder interface {
+	CreateTorrent(r models.CreateTorrentRequest) (*models.Torrent, error)
+}
+
+// Source is a feed to poll and the rules its items must pass. Name keys the
+// seen GUIDs in the store and defaults to the URL.
+type Source struct {
+	Name  string
+	URL   string
+	Rules Rules
+}
+
+// Result is reported for every new item that matched its source's rules.
+type Result struct {
+	Source    string
+	Item      Item
+	Decision  Decision
+	TorrentID int64
+	Err       error
+}
+
+type source struct {
+	Source
+	matcher *Matcher
+}
+
+// Engine polls feeds locally and adds matching items to TorBox.
+type Engine struct {
+	adder   Adder
+	store   Store
+	sources []source
+
+	client   *http.Client
+	interval time.Duration
+	now      func() time.Time
+}
+
+type Option func(*Engine)
+
+// WithStore sets where seen GUIDs are kept, by default they are only kept in
+// memory and every item is considered new after a restart.
+func WithStore(store Store) Option {
+	return func(e *Engine) {
+		e.store = store
+	}
+}
+
+func WithHTTPClient(client *http.Client) Option {
+	return func(e *Engine) {
+		e.client = client
+	}
+}
+
+// WithInterval sets how often Run polls every source.
+func WithInterval(interval time.Duration) Option {
+	return func(e *Engine) {
+		e.interval = interval
+	}
+}
+
+// New compiles every source's rules, returning an error for invalid patterns.
+func New(adder Adder, sources []Source, opts ...Option) (*Engine, error) {
+	e := &Engine{
+		adder: adder,
+		store: NewMemoryStore(),
+
+		client:   &http.Client{Timeout: 30 * time.Second},
+		interval: 15 * time.Minute,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for _, s := range sources {
+		if s.Name == "" {
+			s.Name = s.URL
+		}
+
+		matcher, err := s.Rules.Compile()
+		if err != nil {
+			return nil, fmt.Errorf("source %s: %w", s.Name, err)
+		}
+
+		e.sources = append(e.sources, source{Source: s, matcher: matcher})
+	}
+
+	return e, nil
+}
+
+// Run polls every interval until ctx is done. Poll errors are logged and the
+// failed items retried on the next poll.
+func (e *Engine) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := e.Poll(ctx)
+		if err != nil {
+			log.Warn().Err(err).Msg("rss poll finished with errors")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches every source once and adds new matching items. Items that do
+// not match are marked seen, items that fail to add are not, so they are
+// retried on the next poll.
+func (e *Engine) Poll(ctx context.Context) ([]Result, error) {
+	var results []Result
+	var errs []error
+
+	for _, s := range e.sources {
+		sourceResults, err := e.pollSource(ctx, s)
+		results = append(results, sourceResults...)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %s: %w", s.Name, err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (e *Engine) pollSource(ctx context.Context, s source) ([]Result, error) {
+	feed, err := e.fetchFeed(ctx, s.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	var errs []error
+	now := e.now()
+
+	for _, item := range feed.Items {
+		if e.store.Seen(s.Name, item.GUID) {
+			continue
+		}
+
+		decision := s.matcher.Evaluate(item, now)
+		if decision.Match && item.Magnet == "" && item.TorrentURL == "" {
+			decision = Decision{Reason: "no magnet or .torrent link"}
+		}
+
+		if !decision.Match {
+			log.Debug().Str("source", s.Name).Str("title", item.Title).Str("reason", decision.Reason).Msg("rss item skipped")
+
+			err = e.store.Mark(s.Name, item.GUID)
+			if err != nil {
+				return results, err
+			}
+
+			continue
+		}
+
+		result := Result{
+			Source:   s.Name,
+			Item:     item,
+			Decision: decision,
+		}
+
+		result.TorrentID, result.Err = e.add(ctx, item)
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", item.Title, result.Err))
+		} else {
+			log.Info().Str("source", s.Name).Str("title", item.Title).Int64("torrent_id", result.TorrentID).Msg("rss item added")
+
+			err = e.store.Mark(s.Name, item.GUID)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (e *Engine) add(ctx context.Context, item Item) (int64, error) {
+	name := item.Title
+	request := models.CreateTorrentRequest{
+		Name: &name,
+	}
+
+	if item.Magnet != "" {
+		itemMagnet, err := magnet.NewMagnet(item.Magnet)
+		if err != nil {
+			return 0, err
+		}
+
+		request.Magnet = itemMagnet
+	} else {
+		data, err := e.fetch(ctx, item.TorrentURL)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = torrent.Parse(bytes.NewReader(data))
+		if err != nil {
+			return 0, fmt.Errorf("invalid .torrent enclosure: %w", err)
+		}
+
+		request.File = data
+	}
+
+	created, err := e.adder.CreateTorrent(request)
+	if err != nil {
+		return 0, err
+	}
+
+	if created == nil {
+		return 0, nil
+	}
+
+	return created.ID, nil
+}
+
+func (e *Engine) fetchFeed(ctx context.Context, url string) (*Feed, error) {
+	data, err := e.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(bytes.NewReader(data))
+}
+
+func (e *Engine) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("fetching %s: %s", url, resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxFetchSize))
+}
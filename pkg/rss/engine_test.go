@@ -0,0 +1,188 @@
+package rss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/zeebo/bencode"
+)
+
+const torznabFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:torznab="http://torznab.com/schemas/2015/feed">
+  <channel>
+    <title>Indexer</title>
+    <item>
+      <title>Show.S01E01.1080p.WEB-DL</title>
+      <guid>guid-1</guid>
+      <pubDate>Mon, 01 Jan 2024 10:00:00 +0000</pubDate>
+      <torznab:attr name="size" value="1500000000"/>
+      <torznab:attr name="magneturl" value="magnet:?xt=urn:btih:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa&amp;dn=show"/>
+    </item>
+    <item>
+      <title>Show.S01E02.1080p.WEB-DL</title>
+      <guid>guid-2</guid>
+      <enclosure url="%s/file.torrent" length="2000000000" type="application/x-bittorrent"/>
+    </item>
+    <item>
+      <title>Show.S01E03.720p.CAM</title>
+      <guid>guid-3</guid>
+      <link>magnet:?xt=urn:btih:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb</link>
+    </item>
+    <item>
+      <title>Show.S01E04.1080p.WEB-DL</title>
+      <guid>guid-4</guid>
+      <torznab:attr name="size" value="100"/>
+      <torznab:attr name="magneturl" value="magnet:?xt=urn:btih:cccccccccccccccccccccccccccccccccccccccc"/>
+    </item>
+  </channel>
+</rss>`
+
+const atomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom Tracker</title>
+  <entry>
+    <id>urn:entry:1</id>
+    <title>Movie.2024.1080p</title>
+    <updated>2024-01-01T10:00:00Z</updated>
+    <link rel="alternate" href="https://example.com/movie"/>
+    <link rel="enclosure" type="application/x-bittorrent" href="https://example.com/movie.torrent" length="42"/>
+  </entry>
+</feed>`
+
+type fakeAdder struct {
+	mu       sync.Mutex
+	requests []models.CreateTorrentRequest
+}
+
+func (a *fakeAdder) CreateTorrent(r models.CreateTorrentRequest) (*models.Torrent, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.requests = append(a.requests, r)
+
+	return &models.Torrent{ID: int64(len(a.requests))}, nil
+}
+
+func testTorrent(t *testing.T) []byte {
+	t.Helper()
+
+	data, err := bencode.EncodeBytes(map[string]any{
+		"announce": "udp://tracker.example.com:80",
+		"info": map[string]any{
+			"name":         "Show.S01E02.mkv",
+			"length":       2000000000,
+			"piece length": 262144,
+			"pieces":       "",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParseAtom(t *testing.T) {
+	feed, err := Parse(strings.NewReader(atomFeed))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if feed.Title != "Atom Tracker" || len(feed.Items) != 1 {
+		t.Fatalf("Parse() = %+v, expected one atom entry", feed)
+	}
+
+	item := feed.Items[0]
+	if item.GUID != "urn:entry:1" || item.Link != "https://example.com/movie" || item.TorrentURL != "https://example.com/movie.torrent" || item.Size != 42 {
+		t.Errorf("Parse() item = %+v", item)
+	}
+
+	if item.Published.IsZero() {
+		t.Errorf("Parse() item published not set")
+	}
+}
+
+func TestEnginePoll(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed":
+			fmt.Fprintf(w, torznabFeed, server.URL)
+		case "/file.torrent":
+			w.Write(testTorrent(t))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "seen.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adder := &fakeAdder{}
+	engine, err := New(adder, []Source{
+		{
+			Name: "indexer",
+			URL:  server.URL + "/feed",
+			Rules: Rules{
+				Include: []string{`1080p`},
+				Exclude: []string{`\bCAM\b`},
+				MinSize: 1000,
+			},
+		},
+	}, WithStore(store))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	results, err := engine.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	if len(results) != 2 || len(adder.requests) != 2 {
+		t.Fatalf("Poll() added %d items, expected 2: %+v", len(adder.requests), results)
+	}
+
+	if adder.requests[0].Magnet == nil || adder.requests[0].Magnet.Hash != "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+		t.Errorf("first add should use the torznab magnet, got %+v", adder.requests[0])
+	}
+
+	if len(adder.requests[1].File) == 0 {
+		t.Errorf("second add should upload the .torrent enclosure")
+	}
+
+	for _, guid := range []string{"guid-1", "guid-2", "guid-3", "guid-4"} {
+		if !store.Seen("indexer", guid) {
+			t.Errorf("%s not marked as seen", guid)
+		}
+	}
+
+	// a second poll, even from a reloaded store, adds nothing
+	reloaded, err := NewFileStore(filepath.Join(filepath.Dir(store.path), "seen.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine.store = reloaded
+	results, err = engine.Poll(context.Background())
+	if err != nil || len(results) != 0 || len(adder.requests) != 2 {
+		t.Errorf("second Poll() = %v, %v, expected nothing new", results, err)
+	}
+}
+
+func TestRulesCompileInvalid(t *testing.T) {
+	_, err := New(&fakeAdder{}, []Source{{URL: "http://example.com", Rules: Rules{Include: []string{"("}}}})
+	if err == nil {
+		t.Errorf("New() expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,260 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item is a feed entry normalised across RSS, Atom and Torznab.
+type Item struct {
+	GUID      string
+	Title     string
+	Link      string
+	Published time.Time
+
+	// Size is zero when the feed does not report it.
+	Size int64
+
+	// Magnet or TorrentURL is set when the item carries something TorBox can add.
+	Magnet     string
+	TorrentURL string
+	InfoHash   string
+}
+
+type Feed struct {
+	Title string
+	Items []Item
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type torznabAttr struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type rssItem struct {
+	GUID      string         `xml:"guid"`
+	Title     string         `xml:"title"`
+	Link      string         `xml:"link"`
+	PubDate   string         `xml:"pubDate"`
+	Size      int64          `xml:"size"`
+	Enclosure []rssEnclosure `xml:"enclosure"`
+	Attrs     []torznabAttr  `xml:"http://torznab.com/schemas/2015/feed attr"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Links     []atomLink `xml:"link"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"feed"`
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// Parse reads an RSS 2.0, Torznab or Atom feed.
+func Parse(reader io.Reader) (*Feed, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "rss":
+		var doc rssDocument
+		err = xml.Unmarshal(data, &doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rss feed: %w", err)
+		}
+
+		return parseRSS(doc), nil
+	case "feed":
+		var doc atomDocument
+		err = xml.Unmarshal(data, &doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid atom feed: %w", err)
+		}
+
+		return parseAtom(doc), nil
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", root)
+	}
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", errors.New("no root element found")
+		}
+
+		start, ok := token.(xml.StartElement)
+		if ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func parseRSS(doc rssDocument) *Feed {
+	feed := &Feed{
+		Title: strings.TrimSpace(doc.Channel.Title),
+	}
+
+	for _, i := range doc.Channel.Items {
+		item := Item{
+			GUID:      strings.TrimSpace(i.GUID),
+			Title:     strings.TrimSpace(i.Title),
+			Link:      strings.TrimSpace(i.Link),
+			Published: parseDate(i.PubDate),
+			Size:      i.Size,
+		}
+
+		for _, enclosure := range i.Enclosure {
+			applyLink(&item, enclosure.URL, enclosure.Type)
+			if item.Size == 0 {
+				item.Size = enclosure.Length
+			}
+		}
+
+		for _, attr := range i.Attrs {
+			switch attr.Name {
+			case "size":
+				size, err := strconv.ParseInt(attr.Value, 10, 64)
+				if err == nil {
+					item.Size = size
+				}
+			case "magneturl":
+				item.Magnet = attr.Value
+			case "infohash":
+				item.InfoHash = strings.ToLower(attr.Value)
+			}
+		}
+
+		applyLink(&item, item.Link, "")
+		feed.Items = append(feed.Items, finish(item))
+	}
+
+	return feed
+}
+
+func parseAtom(doc atomDocument) *Feed {
+	feed := &Feed{
+		Title: strings.TrimSpace(doc.Title),
+	}
+
+	for _, e := range doc.Entries {
+		item := Item{
+			GUID:      strings.TrimSpace(e.ID),
+			Title:     strings.TrimSpace(e.Title),
+			Published: parseDate(e.Published),
+		}
+
+		if item.Published.IsZero() {
+			item.Published = parseDate(e.Updated)
+		}
+
+		for _, link := range e.Links {
+			if link.Rel == "" || link.Rel == "alternate" {
+				item.Link = link.Href
+			}
+
+			applyLink(&item, link.Href, link.Type)
+			if link.Rel == "enclosure" && item.Size == 0 {
+				item.Size = link.Length
+			}
+		}
+
+		feed.Items = append(feed.Items, finish(item))
+	}
+
+	return feed
+}
+
+// applyLink records href as the item's magnet or .torrent link when it is one.
+func applyLink(item *Item, href string, mimeType string) {
+	href = strings.TrimSpace(href)
+
+	switch {
+	case strings.HasPrefix(href, "magnet:"):
+		if item.Magnet == "" {
+			item.Magnet = href
+		}
+	case mimeType == "application/x-bittorrent" || strings.HasSuffix(strings.ToLower(href), ".torrent"):
+		if item.TorrentURL == "" {
+			item.TorrentURL = href
+		}
+	}
+}
+
+// finish falls back to the link or title when the feed has no GUID, since
+// the GUID is what the store uses to skip items already handled.
+func finish(item Item) Item {
+	if item.GUID == "" {
+		item.GUID = item.Link
+	}
+
+	if item.GUID == "" {
+		item.GUID = item.Title
+	}
+
+	return item
+}
+
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC822Z,
+	time.RFC822,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2006-01-02 15:04:05",
+}
+
+func parseDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
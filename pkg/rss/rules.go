@@ -0,0 +1,159 @@
+package rss
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Rules filter feed items. An item matches when it matches at least one
+// Include pattern (or there are none), no Exclude pattern, and is within the
+// size and age limits. Zero limits are not checked.
+//
+// Patterns use Go's RE2 syntax. TorBox matches do_regex and dont_regex with
+// its own engine, which also accepts lookarounds and backreferences, so local
+// results for TorBox rules are a close guide rather than a guarantee.
+type Rules struct {
+	Include []string
+	Exclude []string
+
+	MinSize int64
+	MaxSize int64
+	MaxAge  time.Duration
+}
+
+// Decision is the outcome of evaluating one item.
+type Decision struct {
+	Match bool
+
+	// Reason names the pattern or limit that decided the outcome.
+	Reason string
+
+	// Pattern is the include or exclude pattern that matched the title, and
+	// Span the byte offsets of the match within it.
+	Pattern string
+	Span    []int
+}
+
+type Matcher struct {
+	rules   Rules
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// Compile validates the patterns once so they can be evaluated for every item.
+func (r Rules) Compile() (*Matcher, error) {
+	m := &Matcher{
+		rules: r,
+	}
+
+	for _, pattern := range r.Include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+
+		m.include = append(m.include, re)
+	}
+
+	for _, pattern := range r.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+
+		m.exclude = append(m.exclude, re)
+	}
+
+	return m, nil
+}
+
+// CompileSupported compiles the patterns RE2 accepts and returns the rest
+// rather than failing, for rules the server may still consider valid. The
+// returned matcher ignores the unsupported patterns.
+func (r Rules) CompileSupported() (*Matcher, []string) {
+	m := &Matcher{
+		rules: r,
+	}
+
+	var unsupported []string
+	for _, pattern := range r.Include {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			unsupported = append(unsupported, pattern)
+			continue
+		}
+
+		m.include = append(m.include, re)
+	}
+
+	for _, pattern := range r.Exclude {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			unsupported = append(unsupported, pattern)
+			continue
+		}
+
+		m.exclude = append(m.exclude, re)
+	}
+
+	return m, unsupported
+}
+
+func (m *Matcher) Evaluate(item Item, now time.Time) Decision {
+	for _, re := range m.exclude {
+		span := re.FindStringIndex(item.Title)
+		if span != nil {
+			return Decision{
+				Reason:  fmt.Sprintf("excluded by %q", re.String()),
+				Pattern: re.String(),
+				Span:    span,
+			}
+		}
+	}
+
+	included := Decision{Match: true, Reason: "no include patterns"}
+	if len(m.include) > 0 {
+		included = Decision{Reason: "matched no include pattern"}
+		for _, re := range m.include {
+			span := re.FindStringIndex(item.Title)
+			if span != nil {
+				included = Decision{
+					Match:   true,
+					Reason:  fmt.Sprintf("included by %q", re.String()),
+					Pattern: re.String(),
+					Span:    span,
+				}
+				break
+			}
+		}
+
+		if !included.Match {
+			return included
+		}
+	}
+
+	if m.rules.MinSize > 0 && item.Size > 0 && item.Size < m.rules.MinSize {
+		return Decision{Reason: fmt.Sprintf("size %d below minimum %d", item.Size, m.rules.MinSize)}
+	}
+
+	if m.rules.MaxSize > 0 && item.Size > m.rules.MaxSize {
+		return Decision{Reason: fmt.Sprintf("size %d above maximum %d", item.Size, m.rules.MaxSize)}
+	}
+
+	if m.rules.MaxAge > 0 && !item.Published.IsZero() && now.Sub(item.Published) > m.rules.MaxAge {
+		return Decision{Reason: fmt.Sprintf("published %s ago, older than %s", now.Sub(item.Published).Round(time.Minute), m.rules.MaxAge)}
+	}
+
+	return included
+}
+
+// Highlight wraps the part of title that decided the outcome in open and
+// close, or returns title unchanged when no pattern matched.
+func (d Decision) Highlight(title string, open string, close string) string {
+	if len(d.Span) != 2 || d.Span[1] > len(title) {
+		return title
+	}
+
+	return title[:d.Span[0]] + open + title[d.Span[0]:d.Span[1]] + close + title[d.Span[1]:]
+}
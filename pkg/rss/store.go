@@ -0,0 +1,86 @@
+package rss
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// Store remembers which GUIDs have been handled so each item is only added
+// once, however often the feed is polled.
+type Store interface {
+	Seen(source string, guid string) bool
+	Mark(source string, guid string) error
+}
+
+// FileStore is a Store persisted as JSON. It is safe for concurrent use.
+type FileStore struct {
+	path string
+
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+}
+
+// NewFileStore loads the store at path, starting empty if it does not exist.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		path: path,
+		seen: make(map[string]map[string]time.Time),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &s.seen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rss store: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *FileStore) Seen(source string, guid string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.seen[source][guid]
+	return ok
+}
+
+func (s *FileStore) Mark(source string, guid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[source] == nil {
+		s.seen[source] = make(map[string]time.Time)
+	}
+	s.seen[source][guid] = time.Now().UTC()
+
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.seen, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+// NewMemoryStore returns a Store that is not persisted.
+func NewMemoryStore() *FileStore {
+	return &FileStore{
+		seen: make(map[string]map[string]time.Time),
+	}
+}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+// ArchiveVersion is bumped whenever the archive layout changes incompatibly.
+const ArchiveVersion = 1
+
+// Archive is a portable snapshot of everything needed to recreate a library
+// on another account.
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Torrents     []TorrentEntry `json:"torrents"`
+	Usenet       []LinkEntry    `json:"usenet"`
+	WebDownloads []LinkEntry    `json:"web_downloads"`
+	RSSFeeds     []RSSEntry     `json:"rss_feeds"`
+
+	// Skipped lists items that could not be captured, such as torrents
+	// whose magnet and .torrent file could not be exported.
+	Skipped []SkippedEntry `json:"skipped,omitempty"`
+}
+
+type TorrentEntry struct {
+	Hash        string `json:"hash"`
+	Name        string `json:"name"`
+	Magnet      string `json:"magnet,omitempty"`
+	TorrentFile []byte `json:"torrent_file,omitempty"`
+	AllowZipped bool   `json:"allow_zipped"`
+}
+
+type LinkEntry struct {
+	Hash string `json:"hash"`
+	Name string `json:"name"`
+	Link string `json:"link"`
+}
+
+type RSSEntry struct {
+	Feed models.RSSFeed `json:"feed"`
+}
+
+type SkippedEntry struct {
+	Kind   string `json:"kind"`
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (a *Archive) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(a)
+}
+
+func (a *Archive) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = a.Write(file)
+	closeErr := file.Close()
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+	err := json.NewDecoder(r).Decode(&archive)
+	if err != nil {
+		return nil, err
+	}
+
+	if archive.Version < 1 || archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", archive.Version)
+	}
+
+	return &archive, nil
+}
+
+func ReadFile(path string) (*Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Read(file)
+}
@@ -0,0 +1,160 @@
+package backup
+
+import (
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+// Create captures every torrent, queued torrent, usenet download, web
+// download and RSS feed on the account.
+func Create(generalService *general.GeneralService) (*Archive, error) {
+	archive := &Archive{
+		Version:   ArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	activeTorrents, err := generalService.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range activeTorrents {
+		entry, err := torrentEntry(generalService, t)
+		if err != nil {
+			archive.Skipped = append(archive.Skipped, SkippedEntry{
+				Kind:   "torrent",
+				ID:     t.ID,
+				Name:   t.Name,
+				Reason: err.Error(),
+			})
+
+			continue
+		}
+
+		archive.Torrents = append(archive.Torrents, entry)
+	}
+
+	queuedTorrents, err := generalService.GetQueuedTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range queuedTorrents {
+		if q.Magnet == "" {
+			archive.Skipped = append(archive.Skipped, SkippedEntry{
+				Kind:   "queued",
+				ID:     q.ID,
+				Name:   q.Name,
+				Reason: "no stored magnet",
+			})
+
+			continue
+		}
+
+		archive.Torrents = append(archive.Torrents, TorrentEntry{
+			Hash:   q.Hash,
+			Name:   q.Name,
+			Magnet: q.Magnet,
+		})
+	}
+
+	usenetList, err := generalService.GetUsenetList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range usenetList {
+		if u.OriginalURL == "" {
+			archive.Skipped = append(archive.Skipped, SkippedEntry{
+				Kind:   "usenet",
+				ID:     u.ID,
+				Name:   u.Name,
+				Reason: "no original link",
+			})
+
+			continue
+		}
+
+		archive.Usenet = append(archive.Usenet, LinkEntry{
+			Hash: u.Hash,
+			Name: u.Name,
+			Link: u.OriginalURL,
+		})
+	}
+
+	webList, err := generalService.GetWebDownloadList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webList {
+		if w.OriginalURL == "" {
+			archive.Skipped = append(archive.Skipped, SkippedEntry{
+				Kind:   "webdl",
+				ID:     w.ID,
+				Name:   w.Name,
+				Reason: "no original link",
+			})
+
+			continue
+		}
+
+		archive.WebDownloads = append(archive.WebDownloads, LinkEntry{
+			Hash: w.Hash,
+			Name: w.Name,
+			Link: w.OriginalURL,
+		})
+	}
+
+	feeds, err := generalService.GetRSSFeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feed := range feeds {
+		archive.RSSFeeds = append(archive.RSSFeeds, RSSEntry{
+			Feed: feed,
+		})
+	}
+
+	return archive, nil
+}
+
+// torrentEntry keeps the original .torrent for torrents added from a file, so
+// private trackers survive a restore, and falls back to the magnet otherwise.
+func torrentEntry(generalService *general.GeneralService, t models.Torrent) (TorrentEntry, error) {
+	entry := TorrentEntry{
+		Hash:        t.Hash,
+		Name:        t.Name,
+		Magnet:      t.Magnet,
+		AllowZipped: t.AllowZipped,
+	}
+
+	if t.TorrentFile {
+		export, err := generalService.ExportTorrent(t.ID, constants.ExportFormatFile)
+		if err == nil {
+			entry.TorrentFile = export.Data
+			return entry, nil
+		}
+
+		if t.Magnet == "" {
+			return entry, err
+		}
+	}
+
+	if entry.Magnet != "" {
+		return entry, nil
+	}
+
+	export, err := generalService.ExportTorrent(t.ID, constants.ExportFormatMagnet)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.Magnet = *export.Magnet.GetUrl()
+
+	return entry, nil
+}
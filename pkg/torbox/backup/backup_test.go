@@ -0,0 +1,251 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+)
+
+const (
+	ubuntuHash = "0123456789abcdef0123456789abcdef01234567"
+	debianHash = "89abcdef0123456789abcdef0123456789abcdef"
+)
+
+var testArchive = &Archive{
+	Version:   ArchiveVersion,
+	CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	Torrents: []TorrentEntry{
+		{Hash: ubuntuHash, Name: "ubuntu", Magnet: "magnet:?xt=urn:btih:" + ubuntuHash},
+		{Hash: debianHash, Name: "debian", TorrentFile: []byte("d4:infod4:name6:debianee"), AllowZipped: true},
+	},
+	Usenet: []LinkEntry{
+		{Hash: "nzb", Name: "fedora", Link: "https://indexer.example.com/fedora.nzb"},
+	},
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive *Archive
+		wantErr bool
+	}{
+		{
+			name:    "full archive",
+			archive: testArchive,
+		},
+		{
+			name:    "empty archive",
+			archive: &Archive{Version: ArchiveVersion, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "skipped entries are kept",
+			archive: &Archive{
+				Version: ArchiveVersion,
+				Skipped: []SkippedEntry{{Kind: "torrent", ID: 4, Name: "lost", Reason: "export failed"}},
+			},
+		},
+		{
+			name:    "newer version is rejected",
+			archive: &Archive{Version: ArchiveVersion + 1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "backup.json")
+
+			err := tt.archive.WriteFile(path)
+			if err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+
+			read, err := ReadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(read, tt.archive) {
+				t.Errorf("ReadFile() = %+v, want %+v", read, tt.archive)
+			}
+		})
+	}
+}
+
+// fakeAccount serves the list endpoints from the hashes and links already on
+// the account and records every create. Creates for names in fail are
+// rejected.
+type fakeAccount struct {
+	mu      sync.Mutex
+	hashes  []string
+	links   []string
+	fail    map[string]bool
+	created []string
+}
+
+func (f *fakeAccount) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/" + constants.PATH_TORRENTS_GET_ACTIVE:
+		var data []string
+		for _, hash := range f.hashes {
+			data = append(data, fmt.Sprintf(`{"id":1,"hash":%q}`, hash))
+		}
+
+		fmt.Fprintf(w, `{"success":true,"data":[%s]}`, strings.Join(data, ","))
+	case "/" + constants.PATH_USENET_GET_LIST:
+		var data []string
+		for _, link := range f.links {
+			data = append(data, fmt.Sprintf(`{"id":1,"original_url":%q}`, link))
+		}
+
+		fmt.Fprintf(w, `{"success":true,"data":[%s]}`, strings.Join(data, ","))
+	case "/" + constants.PATH_TORRENTS_GET_QUEUED, "/" + constants.PATH_WEBDL_GET_LIST, "/" + constants.PATH_RSS_GET:
+		w.Write([]byte(`{"success":true,"data":[]}`))
+	case "/" + constants.PATH_TORRENTS_CREATE, "/" + constants.PATH_USENET_CREATE:
+		body, _ := io.ReadAll(r.Body)
+
+		for _, name := range []string{"ubuntu", "debian", "fedora"} {
+			if !bytes.Contains(body, []byte(name)) {
+				continue
+			}
+
+			if f.fail[name] {
+				w.Write([]byte(`{"success":false,"detail":"rejected"}`))
+				return
+			}
+
+			f.created = append(f.created, name)
+		}
+
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	default:
+		http.Error(w, "unexpected request", http.StatusNotFound)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name       string
+		hashes     []string
+		links      []string
+		progress   []string
+		fail       []string
+		created    []string
+		duplicates []string
+		wantErr    bool
+	}{
+		{
+			name:    "empty account restores everything",
+			created: []string{"ubuntu", "debian", "fedora"},
+		},
+		{
+			name:       "items on the account are not duplicated",
+			hashes:     []string{strings.ToUpper(ubuntuHash)},
+			links:      []string{"https://indexer.example.com/fedora.nzb"},
+			created:    []string{"debian"},
+			duplicates: []string{torrentKey(ubuntuHash), linkKey("usenet", "https://indexer.example.com/fedora.nzb")},
+		},
+		{
+			name:       "items in the progress file are skipped",
+			progress:   []string{torrentKey(debianHash)},
+			created:    []string{"ubuntu", "fedora"},
+			duplicates: []string{torrentKey(debianHash)},
+		},
+		{
+			name:    "failed items are reported",
+			fail:    []string{"debian"},
+			created: []string{"ubuntu", "fedora"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &fakeAccount{hashes: tt.hashes, links: tt.links, fail: make(map[string]bool)}
+			for _, name := range tt.fail {
+				account.fail[name] = true
+			}
+
+			server := httptest.NewServer(account)
+			defer server.Close()
+
+			service := general.New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			restorer := NewRestorer(service)
+			for _, key := range tt.progress {
+				restorer.done[key] = true
+			}
+
+			report, err := restorer.Restore(context.Background(), testArchive)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(account.created, tt.created) {
+				t.Errorf("created %v, want %v", account.created, tt.created)
+			}
+
+			if !slices.Equal(report.Duplicates, tt.duplicates) {
+				t.Errorf("duplicates %v, want %v", report.Duplicates, tt.duplicates)
+			}
+
+			if len(report.Failed) != len(tt.fail) {
+				t.Errorf("failed %v, want %v", report.Failed, tt.fail)
+			}
+		})
+	}
+}
+
+func TestRestoreResume(t *testing.T) {
+	progress := filepath.Join(t.TempDir(), "progress.json")
+
+	account := &fakeAccount{fail: map[string]bool{"debian": true}}
+	server := httptest.NewServer(account)
+	defer server.Close()
+
+	service := general.New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	// the account list never shows the restored items, so only the progress
+	// file stops them being added twice
+	runs := []struct {
+		fail    bool
+		created []string
+	}{
+		{fail: true, created: []string{"ubuntu", "fedora"}},
+		{fail: false, created: []string{"ubuntu", "fedora", "debian"}},
+		{fail: false, created: []string{"ubuntu", "fedora", "debian"}},
+	}
+
+	for i, run := range runs {
+		account.mu.Lock()
+		account.fail["debian"] = run.fail
+		account.mu.Unlock()
+
+		_, err := NewRestorer(service, WithProgressFile(progress)).Restore(context.Background(), testArchive)
+		if (err != nil) != run.fail {
+			t.Fatalf("run %d: Restore() error = %v", i, err)
+		}
+
+		if !slices.Equal(account.created, run.created) {
+			t.Errorf("run %d: created %v, want %v", i, account.created, run.created)
+		}
+	}
+}
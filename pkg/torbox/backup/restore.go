@@ -0,0 +1,348 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+type Restorer struct {
+	general *general.GeneralService
+
+	progressPath string
+	delay        time.Duration
+	asQueued     bool
+
+	done map[string]bool
+}
+
+type RestoreOption func(*Restorer)
+
+// WithProgressFile records restored items so an interrupted restore can be
+// resumed by running it again with the same file.
+func WithProgressFile(path string) RestoreOption {
+	return func(r *Restorer) {
+		r.progressPath = path
+	}
+}
+
+// WithDelay waits between create calls, on top of the client's rate limiter,
+// to stay within TorBox's stricter per-hour limits on adding downloads.
+func WithDelay(delay time.Duration) RestoreOption {
+	return func(r *Restorer) {
+		r.delay = delay
+	}
+}
+
+// WithAsQueued adds torrents, usenet and web downloads to the queue rather
+// than starting them, so a large restore does not hit active slot limits.
+func WithAsQueued(asQueued bool) RestoreOption {
+	return func(r *Restorer) {
+		r.asQueued = asQueued
+	}
+}
+
+func NewRestorer(generalService *general.GeneralService, opts ...RestoreOption) *Restorer {
+	r := &Restorer{
+		general: generalService,
+
+		done: make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+type RestoreReport struct {
+	Restored   []string
+	Duplicates []string
+	Failed     map[string]error
+}
+
+func (r *RestoreReport) Err() error {
+	var errs []error
+	for key, err := range r.Failed {
+		errs = append(errs, fmt.Errorf("%s: %w", key, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+type restoreItem struct {
+	key    string
+	name   string
+	create func() error
+}
+
+// Restore re-adds every archived item that is not already on the account or
+// recorded in the progress file.
+func (r *Restorer) Restore(ctx context.Context, archive *Archive) (*RestoreReport, error) {
+	err := r.loadProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := r.existingKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{
+		Failed: make(map[string]error),
+	}
+
+	for _, item := range r.items(archive) {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		if r.done[item.key] || existing[item.key] {
+			report.Duplicates = append(report.Duplicates, item.key)
+			continue
+		}
+
+		log.Info().Str("key", item.key).Str("name", item.name).Msg("restoring item")
+
+		err := item.create()
+		if err != nil {
+			report.Failed[item.key] = err
+		} else {
+			report.Restored = append(report.Restored, item.key)
+			r.done[item.key] = true
+			existing[item.key] = true
+
+			err = r.saveProgress()
+			if err != nil {
+				return report, err
+			}
+		}
+
+		if r.delay > 0 {
+			select {
+			case <-ctx.Done():
+				return report, ctx.Err()
+			case <-time.After(r.delay):
+			}
+		}
+	}
+
+	return report, report.Err()
+}
+
+func (r *Restorer) items(archive *Archive) []restoreItem {
+	var items []restoreItem
+	asQueued := r.asQueued
+
+	for _, entry := range archive.Torrents {
+		items = append(items, restoreItem{
+			key:  torrentKey(entry.Hash),
+			name: entry.Name,
+			create: func() error {
+				name := entry.Name
+				request := models.CreateTorrentRequest{
+					Name:     &name,
+					AllowZip: &entry.AllowZipped,
+					AsQueued: &asQueued,
+				}
+
+				if len(entry.TorrentFile) > 0 {
+					request.File = entry.TorrentFile
+				} else {
+					torrentMagnet, err := magnet.NewMagnet(entry.Magnet)
+					if err != nil {
+						return err
+					}
+
+					request.Magnet = torrentMagnet
+				}
+
+				_, err := r.general.CreateTorrent(request)
+				return err
+			},
+		})
+	}
+
+	for _, entry := range archive.Usenet {
+		items = append(items, restoreItem{
+			key:  linkKey("usenet", entry.Link),
+			name: entry.Name,
+			create: func() error {
+				name := entry.Name
+				_, err := r.general.CreateUsenetDownload(models.CreateUsenetRequest{
+					Link:     entry.Link,
+					Name:     &name,
+					AsQueued: &asQueued,
+				})
+
+				return err
+			},
+		})
+	}
+
+	for _, entry := range archive.WebDownloads {
+		items = append(items, restoreItem{
+			key:  linkKey("webdl", entry.Link),
+			name: entry.Name,
+			create: func() error {
+				name := entry.Name
+				_, err := r.general.CreateWebDownload(models.CreateWebDownloadRequest{
+					Link:     entry.Link,
+					Name:     &name,
+					AsQueued: &asQueued,
+				})
+
+				return err
+			},
+		})
+	}
+
+	for _, entry := range archive.RSSFeeds {
+		items = append(items, restoreItem{
+			key:  linkKey("rss", entry.Feed.URL),
+			name: entry.Feed.Name,
+			create: func() error {
+				return r.restoreFeed(entry.Feed)
+			},
+		})
+	}
+
+	return items
+}
+
+func (r *Restorer) restoreFeed(feed models.RSSFeed) error {
+	added, err := r.general.AddRSS(models.AddRSSRequest{
+		RSSRules: feed.RSSRules,
+		URL:      feed.URL,
+		Name:     feed.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	if feed.Enabled || added == nil {
+		return nil
+	}
+
+	return r.general.ControlRSS(added.ID, constants.ControlRSSOperationPause)
+}
+
+// existingKeys collects the keys of everything already on the target account
+// so restores never create duplicates.
+func (r *Restorer) existingKeys() (map[string]bool, error) {
+	existing := make(map[string]bool)
+
+	activeTorrents, err := r.general.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range activeTorrents {
+		existing[torrentKey(t.Hash)] = true
+	}
+
+	queuedTorrents, err := r.general.GetQueuedTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, q := range queuedTorrents {
+		existing[torrentKey(q.Hash)] = true
+	}
+
+	usenetList, err := r.general.GetUsenetList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range usenetList {
+		existing[linkKey("usenet", u.OriginalURL)] = true
+	}
+
+	webList, err := r.general.GetWebDownloadList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webList {
+		existing[linkKey("webdl", w.OriginalURL)] = true
+	}
+
+	feeds, err := r.general.GetRSSFeeds()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, feed := range feeds {
+		existing[linkKey("rss", feed.URL)] = true
+	}
+
+	return existing, nil
+}
+
+func (r *Restorer) loadProgress() error {
+	if r.progressPath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.progressPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var done []string
+	err = json.NewDecoder(bytes.NewReader(data)).Decode(&done)
+	if err != nil {
+		return fmt.Errorf("failed to read restore progress: %w", err)
+	}
+
+	for _, key := range done {
+		r.done[key] = true
+	}
+
+	return nil
+}
+
+func (r *Restorer) saveProgress() error {
+	if r.progressPath == "" {
+		return nil
+	}
+
+	done := make([]string, 0, len(r.done))
+	for key := range r.done {
+		done = append(done, key)
+	}
+
+	data, err := json.MarshalIndent(done, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.progressPath, data, 0o644)
+}
+
+func torrentKey(hash string) string {
+	return "torrent:" + strings.ToLower(hash)
+}
+
+func linkKey(kind string, link string) string {
+	return kind + ":" + link
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/dylanmazurek/go-torbox/internal/ratelimit"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/library"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/search"
 	"github.com/rs/zerolog/log"
 )
@@ -13,6 +15,7 @@ import (
 type Client struct {
 	General *general.GeneralService
 	Search  *search.SearchService
+	Library *library.Library
 }
 
 func New(ctx context.Context, opts ...Option) (*Client, error) {
@@ -38,9 +41,14 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 		},
 	}
 
+	generalService := general.New(*httpAuthClient, clientOptions.apiKey,
+		general.WithLimiter(ratelimit.New(clientOptions.rateLimit, clientOptions.rateLimitBurst)),
+	)
+
 	client := Client{
-		General: general.New(*httpAuthClient, clientOptions.apiKey),
+		General: generalService,
 		Search:  search.New(*httpAuthClient),
+		Library: library.New(generalService),
 	}
 
 	return &client, nil
@@ -0,0 +1,41 @@
+package constants
+
+// APIErrorCode is the machine readable error field TorBox returns alongside
+// the human readable detail.
+type APIErrorCode string
+
+const (
+	APIErrorDatabase              APIErrorCode = "DATABASE_ERROR"
+	APIErrorUnknown               APIErrorCode = "UNKNOWN_ERROR"
+	APIErrorNoAuth                APIErrorCode = "NO_AUTH"
+	APIErrorBadToken              APIErrorCode = "BAD_TOKEN"
+	APIErrorAuth                  APIErrorCode = "AUTH_ERROR"
+	APIErrorInvalidOption         APIErrorCode = "INVALID_OPTION"
+	APIErrorEndpointNotFound      APIErrorCode = "ENDPOINT_NOT_FOUND"
+	APIErrorItemNotFound          APIErrorCode = "ITEM_NOT_FOUND"
+	APIErrorPlanRestricted        APIErrorCode = "PLAN_RESTRICTED_FEATURE"
+	APIErrorDuplicateItem         APIErrorCode = "DUPLICATE_ITEM"
+	APIErrorTooMuchData           APIErrorCode = "TOO_MUCH_DATA"
+	APIErrorDownloadTooLarge      APIErrorCode = "DOWNLOAD_TOO_LARGE"
+	APIErrorMissingRequiredOption APIErrorCode = "MISSING_REQUIRED_OPTION"
+	APIErrorTooManyOptions        APIErrorCode = "TOO_MANY_OPTIONS"
+	APIErrorBozoTorrent           APIErrorCode = "BOZO_TORRENT"
+	APIErrorBozoNZB               APIErrorCode = "BOZO_NZB"
+	APIErrorNoServersAvailable    APIErrorCode = "NO_SERVERS_AVAILABLE_ERROR"
+	APIErrorMonthlyLimit          APIErrorCode = "MONTHLY_LIMIT"
+	APIErrorCooldownLimit         APIErrorCode = "COOLDOWN_LIMIT"
+	APIErrorActiveLimit           APIErrorCode = "ACTIVE_LIMIT"
+	APIErrorDownloadServer        APIErrorCode = "DOWNLOAD_SERVER_ERROR"
+)
+
+// PostProcessing selects what TorBox does with a usenet download once all
+// articles are fetched.
+type PostProcessing int
+
+const (
+	PostProcessingDefault            PostProcessing = -1
+	PostProcessingNone               PostProcessing = 0
+	PostProcessingRepair             PostProcessing = 1
+	PostProcessingRepairUnpack       PostProcessing = 2
+	PostProcessingRepairUnpackDelete PostProcessing = 3
+)
@@ -28,23 +28,35 @@ const (
 	PATH_USENET_CHECK_CACHED   = "api/usenet/checkcached"
 
 	// Web Downloads API
-	PATH_WEBDL_CREATE  = "api/webdl/createwebdownload"
-	PATH_WEBDL_CONTROL = "api/webdl/controlwebdownload"
+	PATH_WEBDL_CREATE       = "api/webdl/createwebdownload"
+	PATH_WEBDL_CONTROL      = "api/webdl/controlwebdownload"
+	PATH_WEBDL_GET_DOWNLOAD = "api/webdl/requestdl"
+	PATH_WEBDL_GET_LIST     = "api/webdl/mylist"
+	PATH_WEBDL_CHECK_CACHED = "api/webdl/checkcached"
+	PATH_WEBDL_HOSTERS      = "api/webdl/hosters"
 
 	// User API
 	PATH_USER_ME            = "api/user/me"
 	PATH_USER_REFRESH_TOKEN = "api/user/refreshtoken"
 	PATH_USER_ADD_REFERRAL  = "api/user/addreferral"
+	PATH_USER_SETTINGS      = "api/user/settings"
+	PATH_USER_EDIT_SETTINGS = "api/user/settings/editsettings"
+	PATH_USER_SUBSCRIPTIONS = "api/user/subscriptions"
+	PATH_USER_TRANSACTIONS  = "api/user/transactions"
 
 	// Notifications API
 	PATH_NOTIFICATIONS_RSS   = "api/notifications/rss"
 	PATH_NOTIFICATIONS_LIST  = "api/notifications/mynotifications"
 	PATH_NOTIFICATIONS_CLEAR = "api/notifications/clear"
+	PATH_NOTIFICATIONS_READ  = "api/notifications/read"
+	PATH_NOTIFICATIONS_TEST  = "api/notifications/test"
 
 	// RSS API
 	PATH_RSS_ADD     = "api/rss/addrss"
 	PATH_RSS_CONTROL = "api/rss/controlrss"
 	PATH_RSS_MODIFY  = "api/rss/modifyrss"
+	PATH_RSS_GET     = "api/rss/getfeeds"
+	PATH_RSS_ITEMS   = "api/rss/getfeeditems"
 
 	// Integration API
 	PATH_INTEGRATION_GOOGLEDRIVE = "api/integration/googledrive"
@@ -53,6 +65,8 @@ const (
 	PATH_INTEGRATION_GOFILE      = "api/integration/gofile"
 	PATH_INTEGRATION_1FICHIER    = "api/integration/1fichier"
 	PATH_INTEGRATION_JOBS        = "api/integration/jobs"
+	PATH_INTEGRATION_JOB         = "api/integration/job"
+	PATH_INTEGRATION_STATUS      = "api/integration/status"
 
 	// Stats API
 	PATH_STATS = "api/stats"
@@ -64,6 +78,21 @@ const (
 	PATH_SEARCH_META     = "meta"
 )
 
+type ExportFormat string
+
+const (
+	ExportFormatMagnet ExportFormat = "magnet"
+	ExportFormatFile   ExportFormat = "file"
+)
+
+// FeedFormat is the syndication format a notification feed is written in.
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+)
+
 type SeedSetting int
 
 const (
@@ -71,3 +100,12 @@ const (
 	Seed
 	NoSeed
 )
+
+// RSSType is the kind of download an RSS feed's matches are added as.
+type RSSType string
+
+const (
+	RSSTypeTorrent     RSSType = "torrent"
+	RSSTypeUsenet      RSSType = "usenet"
+	RSSTypeWebDownload RSSType = "webdl"
+)
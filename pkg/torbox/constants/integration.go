@@ -0,0 +1,163 @@
+package constants
+
+import (
+	"slices"
+	"strings"
+)
+
+// IntegrationProvider is a cloud service TorBox can upload finished files to.
+type IntegrationProvider string
+
+const (
+	IntegrationGoogleDrive IntegrationProvider = "googledrive"
+	IntegrationDropbox     IntegrationProvider = "dropbox"
+	IntegrationOneDrive    IntegrationProvider = "onedrive"
+	IntegrationGofile      IntegrationProvider = "gofile"
+	Integration1Fichier    IntegrationProvider = "1fichier"
+)
+
+// Path returns the provider's integration endpoint, or an empty string for
+// an unknown provider.
+func (p IntegrationProvider) Path() string {
+	return integrationProviders[p].Path
+}
+
+// IntegrationAuthStyle is how a provider is linked to the account.
+type IntegrationAuthStyle string
+
+const (
+	// IntegrationAuthOAuth providers take an authorization code from the
+	// provider's OAuth consent flow.
+	IntegrationAuthOAuth IntegrationAuthStyle = "oauth"
+	// IntegrationAuthAPIKey providers take an API key from the provider's
+	// account settings.
+	IntegrationAuthAPIKey IntegrationAuthStyle = "api_key"
+)
+
+// IntegrationCapability is a set of upload features a provider is expected to
+// support. The sets are descriptive only, TorBox decides what it accepts.
+type IntegrationCapability uint8
+
+const (
+	// IntegrationUploadFile uploads a single file of a download.
+	IntegrationUploadFile IntegrationCapability = 1 << iota
+	// IntegrationUploadZip uploads a whole download as one archive.
+	IntegrationUploadZip
+	// IntegrationUploadFolder uploads a whole download keeping its folders.
+	IntegrationUploadFolder
+)
+
+func (c IntegrationCapability) Has(capability IntegrationCapability) bool {
+	return c&capability == capability
+}
+
+// IntegrationProviderInfo describes how to authorize and upload to a provider.
+type IntegrationProviderInfo struct {
+	Provider     IntegrationProvider
+	Name         string
+	Auth         IntegrationAuthStyle
+	Path         string
+	Capabilities IntegrationCapability
+
+	// OAuthURL and OAuthScopes are where and for what OAuth providers ask
+	// the user for consent.
+	OAuthURL    string
+	OAuthScopes []string
+}
+
+// integrationProviders is the registry of supported providers. Adding a
+// provider only needs a new entry here.
+var integrationProviders = map[IntegrationProvider]IntegrationProviderInfo{
+	IntegrationGoogleDrive: {
+		Name:         "Google Drive",
+		Auth:         IntegrationAuthOAuth,
+		Path:         PATH_INTEGRATION_GOOGLEDRIVE,
+		Capabilities: IntegrationUploadFile | IntegrationUploadZip | IntegrationUploadFolder,
+		OAuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+		OAuthScopes:  []string{"https://www.googleapis.com/auth/drive.file"},
+	},
+	IntegrationDropbox: {
+		Name:         "Dropbox",
+		Auth:         IntegrationAuthOAuth,
+		Path:         PATH_INTEGRATION_DROPBOX,
+		Capabilities: IntegrationUploadFile | IntegrationUploadZip | IntegrationUploadFolder,
+		OAuthURL:     "https://www.dropbox.com/oauth2/authorize",
+		OAuthScopes:  []string{"files.content.write"},
+	},
+	IntegrationOneDrive: {
+		Name:         "OneDrive",
+		Auth:         IntegrationAuthOAuth,
+		Path:         PATH_INTEGRATION_ONEDRIVE,
+		Capabilities: IntegrationUploadFile | IntegrationUploadZip | IntegrationUploadFolder,
+		OAuthURL:     "https://login.microsoftonline.com/common/oauth2/v2.0/authorize",
+		OAuthScopes:  []string{"Files.ReadWrite", "offline_access"},
+	},
+	IntegrationGofile: {
+		Name:         "Gofile",
+		Auth:         IntegrationAuthAPIKey,
+		Path:         PATH_INTEGRATION_GOFILE,
+		Capabilities: IntegrationUploadFile | IntegrationUploadZip,
+	},
+	Integration1Fichier: {
+		Name:         "1Fichier",
+		Auth:         IntegrationAuthAPIKey,
+		Path:         PATH_INTEGRATION_1FICHIER,
+		Capabilities: IntegrationUploadFile | IntegrationUploadZip,
+	},
+}
+
+// Info returns the provider's registry entry.
+func (p IntegrationProvider) Info() (IntegrationProviderInfo, bool) {
+	info, ok := integrationProviders[p]
+	if !ok {
+		return IntegrationProviderInfo{}, false
+	}
+
+	info.Provider = p
+
+	return info, true
+}
+
+// IntegrationProviders lists every registered provider, ordered by name.
+func IntegrationProviders() []IntegrationProviderInfo {
+	providers := make([]IntegrationProviderInfo, 0, len(integrationProviders))
+	for provider := range integrationProviders {
+		info, _ := provider.Info()
+		providers = append(providers, info)
+	}
+
+	slices.SortFunc(providers, func(a, b IntegrationProviderInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return providers
+}
+
+// IntegrationItemType is the kind of download a transfer reads from.
+type IntegrationItemType string
+
+const (
+	IntegrationItemTorrent     IntegrationItemType = "torrent"
+	IntegrationItemUsenet      IntegrationItemType = "usenet"
+	IntegrationItemWebDownload IntegrationItemType = "webdl"
+)
+
+type IntegrationJobStatus string
+
+const (
+	IntegrationJobPending   IntegrationJobStatus = "pending"
+	IntegrationJobUploading IntegrationJobStatus = "uploading"
+	IntegrationJobCompleted IntegrationJobStatus = "completed"
+	IntegrationJobFailed    IntegrationJobStatus = "failed"
+	IntegrationJobCancelled IntegrationJobStatus = "cancelled"
+)
+
+// IsTerminal reports whether the job has stopped and will not change again.
+func (s IntegrationJobStatus) IsTerminal() bool {
+	switch s {
+	case IntegrationJobCompleted, IntegrationJobFailed, IntegrationJobCancelled:
+		return true
+	default:
+		return false
+	}
+}
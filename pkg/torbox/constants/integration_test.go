@@ -0,0 +1,25 @@
+package constants
+
+import "testing"
+
+func TestIntegrationProviderPath(t *testing.T) {
+	tests := []struct {
+		provider IntegrationProvider
+		expected string
+	}{
+		{provider: IntegrationGoogleDrive, expected: PATH_INTEGRATION_GOOGLEDRIVE},
+		{provider: IntegrationDropbox, expected: PATH_INTEGRATION_DROPBOX},
+		{provider: IntegrationOneDrive, expected: PATH_INTEGRATION_ONEDRIVE},
+		{provider: IntegrationGofile, expected: PATH_INTEGRATION_GOFILE},
+		{provider: Integration1Fichier, expected: PATH_INTEGRATION_1FICHIER},
+		{provider: "mega", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.provider), func(t *testing.T) {
+			if got := tt.provider.Path(); got != tt.expected {
+				t.Errorf("Path() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
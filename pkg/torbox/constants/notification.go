@@ -0,0 +1,26 @@
+package constants
+
+// NotificationType is the kind of event a notification reports.
+type NotificationType string
+
+const (
+	NotificationDownloadReady  NotificationType = "download_ready"
+	NotificationDownloadFailed NotificationType = "download_failed"
+	NotificationDownloadQueued NotificationType = "download_queued"
+	NotificationRSS            NotificationType = "rss"
+	NotificationIntegration    NotificationType = "integration"
+	NotificationSystem         NotificationType = "system"
+	NotificationTest           NotificationType = "test"
+)
+
+// Known reports whether t is one of the types above. TorBox may add new types
+// at any time, so unknown values are kept rather than rejected.
+func (t NotificationType) Known() bool {
+	switch t {
+	case NotificationDownloadReady, NotificationDownloadFailed, NotificationDownloadQueued,
+		NotificationRSS, NotificationIntegration, NotificationSystem, NotificationTest:
+		return true
+	default:
+		return false
+	}
+}
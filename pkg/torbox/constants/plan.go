@@ -0,0 +1,157 @@
+package constants
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Plan is a TorBox subscription tier. The API reports plans by number, older
+// responses by name, and both decode to the same value. Names this package
+// does not know decode to PlanUnknown rather than being mistaken for free.
+type Plan int
+
+const (
+	PlanUnknown   Plan = -1
+	PlanFree      Plan = 0
+	PlanEssential Plan = 1
+	PlanPro       Plan = 2
+	PlanStandard  Plan = 3
+)
+
+const (
+	gigabyte = int64(1) << 30
+	terabyte = int64(1) << 40
+)
+
+// PlanLimits approximate the caps TorBox applies to a plan. They are taken
+// from the public pricing page and may lag behind it, so use them as hints
+// and let the API enforce the real limits.
+type PlanLimits struct {
+	// ActiveSlots is how many downloads of each kind may be active at once.
+	ActiveSlots int
+	// MaxDownloadSize is the largest single download in bytes, 0 means no cap.
+	MaxDownloadSize int64
+	// RequestsPerMinute is the general API budget.
+	RequestsPerMinute int
+	// CreatesPerHour is the budget for adding torrents, usenet and web downloads.
+	CreatesPerHour int
+}
+
+// AllowsSize reports whether a download of size bytes fits the plan.
+func (l PlanLimits) AllowsSize(size int64) bool {
+	return l.MaxDownloadSize == 0 || size <= l.MaxDownloadSize
+}
+
+var planNames = map[Plan]string{
+	PlanFree:      "free",
+	PlanEssential: "essential",
+	PlanPro:       "pro",
+	PlanStandard:  "standard",
+}
+
+var planLimits = map[Plan]PlanLimits{
+	PlanFree: {
+		ActiveSlots:       1,
+		MaxDownloadSize:   10 * gigabyte,
+		RequestsPerMinute: 60,
+		CreatesPerHour:    10,
+	},
+	PlanEssential: {
+		ActiveSlots:       3,
+		MaxDownloadSize:   200 * gigabyte,
+		RequestsPerMinute: 300,
+		CreatesPerHour:    60,
+	},
+	PlanStandard: {
+		ActiveSlots:       5,
+		MaxDownloadSize:   1 * terabyte,
+		RequestsPerMinute: 300,
+		CreatesPerHour:    60,
+	},
+	PlanPro: {
+		ActiveSlots:       10,
+		RequestsPerMinute: 300,
+		CreatesPerHour:    60,
+	},
+}
+
+// ParsePlan accepts a plan's number or name.
+func ParsePlan(value string) (Plan, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	number, err := strconv.Atoi(value)
+	if err == nil {
+		plan := Plan(number)
+		if _, ok := planNames[plan]; ok {
+			return plan, nil
+		}
+	}
+
+	for plan, name := range planNames {
+		if name == value {
+			return plan, nil
+		}
+	}
+
+	return PlanUnknown, fmt.Errorf("unknown plan %q", value)
+}
+
+// Known reports whether the plan is one this package has limits for.
+func (p Plan) Known() bool {
+	_, ok := planLimits[p]
+	return ok
+}
+
+func (p Plan) String() string {
+	if p == PlanUnknown {
+		return "unknown"
+	}
+
+	name, ok := planNames[p]
+	if !ok {
+		return strconv.Itoa(int(p))
+	}
+
+	return name
+}
+
+// Limits returns the plan's caps. Unknown plans get the free plan's limits,
+// check Known before relying on them.
+func (p Plan) Limits() PlanLimits {
+	limits, ok := planLimits[p]
+	if !ok {
+		return planLimits[PlanFree]
+	}
+
+	return limits
+}
+
+func (p *Plan) UnmarshalJSON(d []byte) error {
+	if bytes.Equal(d, []byte("null")) {
+		*p = PlanUnknown
+		return nil
+	}
+
+	var number int
+	err := json.Unmarshal(d, &number)
+	if err == nil {
+		*p = Plan(number)
+		return nil
+	}
+
+	var name string
+	err = json.Unmarshal(d, &name)
+	if err != nil {
+		return fmt.Errorf("invalid plan %s", d)
+	}
+
+	// unknown names are kept as PlanUnknown rather than failing the whole
+	// response
+	plan, _ := ParsePlan(name)
+	*p = plan
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package constants
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPlanUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Plan
+		wantErr  bool
+	}{
+		{name: "number", input: `2`, expected: PlanPro},
+		{name: "name", input: `"Essential"`, expected: PlanEssential},
+		{name: "numeric string", input: `"3"`, expected: PlanStandard},
+		{name: "unknown name", input: `"platinum"`, expected: PlanUnknown},
+		{name: "null", input: `null`, expected: PlanUnknown},
+		{name: "invalid", input: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Plan
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.expected {
+				t.Errorf("UnmarshalJSON() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanLimits(t *testing.T) {
+	tests := []struct {
+		plan    Plan
+		known   bool
+		slots   int
+		size    int64
+		allowed bool
+	}{
+		{plan: PlanFree, known: true, slots: 1, size: 20 * gigabyte, allowed: false},
+		{plan: PlanEssential, known: true, slots: 3, size: 20 * gigabyte, allowed: true},
+		{plan: PlanStandard, known: true, slots: 5, size: 2 * terabyte, allowed: false},
+		{plan: PlanPro, known: true, slots: 10, size: 2 * terabyte, allowed: true},
+		{plan: Plan(42), slots: 1, size: gigabyte, allowed: true},
+		{plan: PlanUnknown, slots: 1, size: gigabyte, allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.plan.String(), func(t *testing.T) {
+			if tt.plan.Known() != tt.known {
+				t.Errorf("Known() = %v, want %v", !tt.known, tt.known)
+			}
+
+			limits := tt.plan.Limits()
+			if limits.ActiveSlots != tt.slots {
+				t.Errorf("ActiveSlots = %d, want %d", limits.ActiveSlots, tt.slots)
+			}
+
+			if limits.AllowsSize(tt.size) != tt.allowed {
+				t.Errorf("AllowsSize(%d) = %v, want %v", tt.size, !tt.allowed, tt.allowed)
+			}
+		})
+	}
+}
@@ -1,52 +1,236 @@
 package constants
 
-type TorrentState string
+// DownloadState is the download_state reported for torrents, usenet and web
+// downloads. States TorBox adds later are preserved as-is, use IsKnown to tell
+// them apart from the states listed here.
+type DownloadState string
+
+// TorrentState is retained for existing callers, it is the same type as DownloadState.
+type TorrentState = DownloadState
 
 const (
 	// ---- processing states
 	// checkingResumeData	The torrent is checking resumable data.
-	TorrentStateCheckingResumeData TorrentState = "checkingResumeData"
-	// checking
-	TorrentStateChecking = "checking"
+	TorrentStateCheckingResumeData DownloadState = "checkingResumeData"
+	// checking			The download is being checked.
+	TorrentStateChecking DownloadState = "checking"
+	// checkingDL		The torrent is checking data while downloading.
+	TorrentStateCheckingDL DownloadState = "checkingDL"
+	// checkingUP		The torrent has finished downloading and is being checked.
+	TorrentStateCheckingUP DownloadState = "checkingUP"
 	// metaDL			The torrent is downloading metadata from the hoard.
-	TorrentStateMetaDL = "metaDL"
-	// paused			The torrent is paused.
-	TorrentStatePaused = "paused"
+	TorrentStateMetaDL DownloadState = "metaDL"
+	// allocating		Disk space is being allocated for the torrent.
+	TorrentStateAllocating DownloadState = "allocating"
+	// moving			The torrent is being moved to its final location.
+	TorrentStateMoving DownloadState = "moving"
+	// repairing		The usenet download is being repaired from par2 data.
+	UsenetStateRepairing DownloadState = "repairing"
+	// extracting		The usenet download is being extracted.
+	UsenetStateExtracting DownloadState = "extracting"
+
+	// ---- paused states
+	// paused			The download is paused.
+	TorrentStatePaused DownloadState = "paused"
+	// pausedDL			The torrent is paused and has not finished downloading.
+	TorrentStatePausedDL DownloadState = "pausedDL"
+	// pausedUP			The torrent is paused and has finished downloading.
+	TorrentStatePausedUP DownloadState = "pausedUP"
 
 	// ---- download states
-	// downloading		The torrent is currently downloading.
-	TorrentStateDownloading = "downloading"
+	// downloading		The download is in progress.
+	TorrentStateDownloading DownloadState = "downloading"
+	// forcedDL			The torrent is downloading, ignoring queue limits.
+	TorrentStateForcedDL DownloadState = "forcedDL"
 	// stalled (no seeds)	The torrent is trying to download, but there are no seeds connected to download from.
-	TorrentStateStalledNoSeeds = "stalled (no seeds)"
-	// stalled
-	TorrentStateStalledDL = "stalledDL"
+	TorrentStateStalledNoSeeds DownloadState = "stalled (no seeds)"
+	// stalledDL		The torrent is trying to download, but no data is being received.
+	TorrentStateStalledDL DownloadState = "stalledDL"
 
 	// ---- upload states
 	// uploading		The torrent is currently seeding.
-	TorrentStateUploading = "uploading"
+	TorrentStateUploading DownloadState = "uploading"
 	// uploading (no peers) The torrent is currently seeding, but there are no peers connected to upload to.
-	TorrentStateUploadingNoPeers = "uploading (no peers)"
+	TorrentStateUploadingNoPeers DownloadState = "uploading (no peers)"
+	// stalledUP		The torrent is seeding, but no data is being sent.
+	TorrentStateStalledUP DownloadState = "stalledUP"
+	// forcedUP			The torrent is seeding, ignoring queue limits.
+	TorrentStateForcedUP DownloadState = "forcedUP"
+
+	// ---- queue states
+	// queued			The download is waiting in the TorBox queue.
+	TorrentStateQueued DownloadState = "queued"
+	// queuedDL			The torrent is queued to download.
+	TorrentStateQueuedDL DownloadState = "queuedDL"
+	// queuedUP			The torrent is queued to seed.
+	TorrentStateQueuedUP DownloadState = "queuedUP"
 
 	// ---- completion states
 	// completed		The torrent is completely downloaded. Do not use this for download completion status.
-	TorrentStateCompleted = "completed"
+	TorrentStateCompleted DownloadState = "completed"
 	// cached			The torrent is cached from the server.
-	TorrentStateCached = "cached"
+	TorrentStateCached DownloadState = "cached"
 
 	// ---- error states
+	// error			The torrent has hit an error.
+	TorrentStateError DownloadState = "error"
+	// missingFiles		The torrent data is missing from the server.
+	TorrentStateMissingFiles DownloadState = "missingFiles"
+	// failed			The usenet or web download has failed.
+	DownloadStateFailed DownloadState = "failed"
 	// unknown			The torrent state is unknown.
-	TorrentStateUnknown = "unknown"
+	TorrentStateUnknown DownloadState = "unknown"
 )
 
-func (t TorrentState) IsComplete() bool {
+var knownStates = map[DownloadState]bool{
+	TorrentStateCheckingResumeData: true,
+	TorrentStateChecking:           true,
+	TorrentStateCheckingDL:         true,
+	TorrentStateCheckingUP:         true,
+	TorrentStateMetaDL:             true,
+	TorrentStateAllocating:         true,
+	TorrentStateMoving:             true,
+	UsenetStateRepairing:           true,
+	UsenetStateExtracting:          true,
+	TorrentStatePaused:             true,
+	TorrentStatePausedDL:           true,
+	TorrentStatePausedUP:           true,
+	TorrentStateDownloading:        true,
+	TorrentStateForcedDL:           true,
+	TorrentStateStalledNoSeeds:     true,
+	TorrentStateStalledDL:          true,
+	TorrentStateUploading:          true,
+	TorrentStateUploadingNoPeers:   true,
+	TorrentStateStalledUP:          true,
+	TorrentStateForcedUP:           true,
+	TorrentStateQueued:             true,
+	TorrentStateQueuedDL:           true,
+	TorrentStateQueuedUP:           true,
+	TorrentStateCompleted:          true,
+	TorrentStateCached:             true,
+	TorrentStateError:              true,
+	TorrentStateMissingFiles:       true,
+	DownloadStateFailed:            true,
+	TorrentStateUnknown:            true,
+}
+
+// IsKnown reports whether the state is one of the states defined in this package.
+func (t DownloadState) IsKnown() bool {
+	return knownStates[t]
+}
+
+func (t DownloadState) IsComplete() bool {
 	switch t {
 	case TorrentStateCompleted,
 		TorrentStateCached,
 		TorrentStateUploading,
-		TorrentStateUploadingNoPeers:
+		TorrentStateUploadingNoPeers,
+		TorrentStateStalledUP,
+		TorrentStateForcedUP,
+		TorrentStatePausedUP,
+		TorrentStateQueuedUP,
+		TorrentStateCheckingUP:
+
+		return true
+	default:
+		return false
+	}
+}
+
+// IsActive reports whether the download is occupying an active slot, whether
+// or not data is currently moving.
+func (t DownloadState) IsActive() bool {
+	switch t {
+	case TorrentStateCheckingResumeData,
+		TorrentStateChecking,
+		TorrentStateCheckingDL,
+		TorrentStateCheckingUP,
+		TorrentStateMetaDL,
+		TorrentStateAllocating,
+		TorrentStateMoving,
+		UsenetStateRepairing,
+		UsenetStateExtracting,
+		TorrentStateDownloading,
+		TorrentStateForcedDL,
+		TorrentStateStalledNoSeeds,
+		TorrentStateStalledDL:
+
+		return true
+	default:
+		return t.IsSeeding()
+	}
+}
+
+func (t DownloadState) IsError() bool {
+	switch t {
+	case TorrentStateError,
+		TorrentStateMissingFiles,
+		DownloadStateFailed:
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (t DownloadState) IsStalled() bool {
+	switch t {
+	case TorrentStateStalledNoSeeds,
+		TorrentStateStalledDL,
+		TorrentStateStalledUP:
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (t DownloadState) IsSeeding() bool {
+	switch t {
+	case TorrentStateUploading,
+		TorrentStateUploadingNoPeers,
+		TorrentStateStalledUP,
+		TorrentStateForcedUP:
+
+		return true
+	default:
+		return false
+	}
+}
+
+func (t DownloadState) IsPaused() bool {
+	switch t {
+	case TorrentStatePaused,
+		TorrentStatePausedDL,
+		TorrentStatePausedUP:
 
 		return true
 	default:
 		return false
 	}
 }
+
+func (t DownloadState) IsQueued() bool {
+	switch t {
+	case TorrentStateQueued,
+		TorrentStateQueuedDL,
+		TorrentStateQueuedUP:
+
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTerminal reports whether the download will not make further progress
+// without intervention, either because it finished or because it failed.
+func (t DownloadState) IsTerminal() bool {
+	switch t {
+	case TorrentStateCompleted,
+		TorrentStateCached:
+
+		return true
+	default:
+		return t.IsError()
+	}
+}
@@ -0,0 +1,77 @@
+package constants
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDownloadStateCategories(t *testing.T) {
+	tests := []struct {
+		state    DownloadState
+		active   bool
+		error    bool
+		stalled  bool
+		seeding  bool
+		terminal bool
+	}{
+		{state: TorrentStateDownloading, active: true},
+		{state: TorrentStateMetaDL, active: true},
+		{state: TorrentStateStalledNoSeeds, active: true, stalled: true},
+		{state: TorrentStateStalledUP, active: true, stalled: true, seeding: true},
+		{state: TorrentStateUploading, active: true, seeding: true},
+		{state: TorrentStatePaused},
+		{state: TorrentStateQueued},
+		{state: TorrentStateCompleted, terminal: true},
+		{state: TorrentStateCached, terminal: true},
+		{state: TorrentStateError, error: true, terminal: true},
+		{state: DownloadStateFailed, error: true, terminal: true},
+		{state: DownloadState("somethingNew")},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			if got := tt.state.IsActive(); got != tt.active {
+				t.Errorf("IsActive() = %v, expected %v", got, tt.active)
+			}
+
+			if got := tt.state.IsError(); got != tt.error {
+				t.Errorf("IsError() = %v, expected %v", got, tt.error)
+			}
+
+			if got := tt.state.IsStalled(); got != tt.stalled {
+				t.Errorf("IsStalled() = %v, expected %v", got, tt.stalled)
+			}
+
+			if got := tt.state.IsSeeding(); got != tt.seeding {
+				t.Errorf("IsSeeding() = %v, expected %v", got, tt.seeding)
+			}
+
+			if got := tt.state.IsTerminal(); got != tt.terminal {
+				t.Errorf("IsTerminal() = %v, expected %v", got, tt.terminal)
+			}
+		})
+	}
+}
+
+func TestDownloadStatePreservesUnknown(t *testing.T) {
+	var aux struct {
+		DownloadState DownloadState `json:"download_state"`
+	}
+
+	err := json.Unmarshal([]byte(`{"download_state": "stalledSomewhere"}`), &aux)
+	if err != nil {
+		t.Fatalf("Unmarshal() unexpected error = %v", err)
+	}
+
+	if aux.DownloadState != "stalledSomewhere" {
+		t.Errorf("DownloadState = %v, expected %v", aux.DownloadState, "stalledSomewhere")
+	}
+
+	if aux.DownloadState.IsKnown() {
+		t.Errorf("IsKnown() = true, expected false")
+	}
+
+	if !TorrentStateMoving.IsKnown() {
+		t.Errorf("IsKnown() = false for %v, expected true", TorrentStateMoving)
+	}
+}
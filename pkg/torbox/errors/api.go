@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+)
+
+var (
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrInvalidRequest   = errors.New("invalid request")
+	ErrPlanRestricted   = errors.New("feature not available on this plan")
+	ErrDuplicateItem    = errors.New("item already exists")
+	ErrLimitReached     = errors.New("download limit reached")
+	ErrDownloadTooLarge = errors.New("download too large")
+	ErrInvalidFile      = errors.New("invalid torrent or nzb file")
+)
+
+// apiErrors maps TorBox error codes onto the package sentinels so callers can
+// use errors.Is without matching on strings.
+var apiErrors = map[constants.APIErrorCode]error{
+	constants.APIErrorDatabase:              ErrServerError,
+	constants.APIErrorUnknown:               ErrServerError,
+	constants.APIErrorNoServersAvailable:    ErrServerError,
+	constants.APIErrorDownloadServer:        ErrServerError,
+	constants.APIErrorNoAuth:                ErrUnauthorized,
+	constants.APIErrorBadToken:              ErrUnauthorized,
+	constants.APIErrorAuth:                  ErrUnauthorized,
+	constants.APIErrorInvalidOption:         ErrInvalidRequest,
+	constants.APIErrorMissingRequiredOption: ErrInvalidRequest,
+	constants.APIErrorTooManyOptions:        ErrInvalidRequest,
+	constants.APIErrorEndpointNotFound:      ErrInvalidRequest,
+	constants.APIErrorItemNotFound:          ErrDownloadNotFound,
+	constants.APIErrorPlanRestricted:        ErrPlanRestricted,
+	constants.APIErrorDuplicateItem:         ErrDuplicateItem,
+	constants.APIErrorMonthlyLimit:          ErrLimitReached,
+	constants.APIErrorCooldownLimit:         ErrLimitReached,
+	constants.APIErrorActiveLimit:           ErrLimitReached,
+	constants.APIErrorTooMuchData:           ErrDownloadTooLarge,
+	constants.APIErrorDownloadTooLarge:      ErrDownloadTooLarge,
+	constants.APIErrorBozoTorrent:           ErrInvalidFile,
+	constants.APIErrorBozoNZB:               ErrInvalidFile,
+}
+
+// APIError is an error reported by TorBox, either through an HTTP error
+// status or a response with success set to false.
+type APIError struct {
+	// StatusCode is zero when the API answered 200 with success false.
+	StatusCode int
+	Code       constants.APIErrorCode
+	Detail     string
+}
+
+func NewAPIError(statusCode int, code string, detail string) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Code:       constants.APIErrorCode(code),
+		Detail:     detail,
+	}
+}
+
+func (e *APIError) Error() string {
+	var msg string
+	switch {
+	case e.Code != "" && e.Detail != "":
+		msg = fmt.Sprintf("%s - %s", e.Code, e.Detail)
+	case e.Code != "":
+		msg = string(e.Code)
+	case e.Detail != "":
+		msg = e.Detail
+	case e.StatusCode != 0:
+		return fmt.Sprintf("torbox server error (status: %d)", e.StatusCode)
+	default:
+		return "torbox API error"
+	}
+
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("torbox API error: %s (status: %d)", msg, e.StatusCode)
+	}
+
+	return msg
+}
+
+// Unwrap returns the sentinel for the error code, falling back to
+// ErrServerError for 5xx responses with unrecognised codes.
+func (e *APIError) Unwrap() error {
+	sentinel, ok := apiErrors[e.Code]
+	if ok {
+		return sentinel
+	}
+
+	if e.StatusCode >= 500 {
+		return ErrServerError
+	}
+
+	return nil
+}
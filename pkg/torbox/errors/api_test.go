@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorUnwrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *APIError
+		expected error
+		message  string
+	}{
+		{
+			name:     "limit code",
+			err:      NewAPIError(0, "ACTIVE_LIMIT", "too many active downloads"),
+			expected: ErrLimitReached,
+			message:  "ACTIVE_LIMIT - too many active downloads",
+		},
+		{
+			name:     "auth code with status",
+			err:      NewAPIError(403, "BAD_TOKEN", ""),
+			expected: ErrUnauthorized,
+			message:  "torbox API error: BAD_TOKEN (status: 403)",
+		},
+		{
+			name:     "bad nzb",
+			err:      NewAPIError(400, "BOZO_NZB", "could not parse nzb"),
+			expected: ErrInvalidFile,
+			message:  "torbox API error: BOZO_NZB - could not parse nzb (status: 400)",
+		},
+		{
+			name:     "unknown code on server error",
+			err:      NewAPIError(502, "", ""),
+			expected: ErrServerError,
+			message:  "torbox server error (status: 502)",
+		},
+		{
+			name:    "unknown code",
+			err:     NewAPIError(0, "SOMETHING_NEW", "detail"),
+			message: "SOMETHING_NEW - detail",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.message {
+				t.Errorf("Error() = %q, want %q", got, tt.message)
+			}
+
+			if tt.expected == nil {
+				if tt.err.Unwrap() != nil {
+					t.Errorf("Unwrap() = %v, want nil", tt.err.Unwrap())
+				}
+				return
+			}
+
+			if !errors.Is(tt.err, tt.expected) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.expected)
+			}
+		})
+	}
+}
@@ -6,4 +6,16 @@ var (
 	ErrServerError           = errors.New("server error")
 	ErrDownloadAlreadyQueued = errors.New("download already queued")
 	ErrInvalidMagnetLink     = errors.New("invalid magnet link")
+	ErrDownloadNotFinished   = errors.New("download not finished")
+	ErrDownloadNotFound      = errors.New("download not found")
+	ErrUnsupportedOperation  = errors.New("operation not supported for this download kind")
+	ErrInsufficientSpace     = errors.New("insufficient storage space")
+	ErrNoTorrentSource       = errors.New("no hash, magnet or torrent file given")
+	ErrUnsupportedHoster     = errors.New("link is not from a supported hoster")
+	ErrInvalidRSSRule        = errors.New("invalid rss rule")
+	ErrIntegrationJobFailed  = errors.New("integration job failed")
+	ErrUnknownIntegration    = errors.New("unknown integration provider")
+	ErrOAuthStateMismatch    = errors.New("oauth state mismatch")
+	ErrOAuthDenied           = errors.New("oauth authorization denied")
+	ErrInvalidSettings       = errors.New("invalid user settings")
 )
@@ -0,0 +1,267 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/mirror"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+type Policy string
+
+const (
+	// PolicyWarn only reports expiring torrents.
+	PolicyWarn Policy = "warn"
+	// PolicyReadd adds the torrent again from its stored magnet, or an exported
+	// .torrent file when no magnet is stored, to renew it.
+	PolicyReadd Policy = "readd"
+	// PolicyDownload mirrors the torrent's files to a local directory before it expires.
+	PolicyDownload Policy = "download"
+)
+
+type Item struct {
+	Torrent   models.Torrent
+	ExpiresAt time.Time
+	Remaining time.Duration
+}
+
+type Result struct {
+	Item
+
+	Policy Policy
+
+	// RenewedID is set when PolicyReadd created a new torrent.
+	RenewedID int64
+	Err       error
+}
+
+type Manager struct {
+	general *general.GeneralService
+	mirror  *mirror.Mirror
+
+	policy      Policy
+	downloadDir string
+	onWarning   func(Item)
+	now         func() time.Time
+}
+
+type Option func(*Manager)
+
+func WithPolicy(policy Policy) Option {
+	return func(m *Manager) {
+		m.policy = policy
+	}
+}
+
+// WithDownloadDir sets where PolicyDownload mirrors files, each torrent is
+// synced into its own AbsolutePath structure beneath it.
+func WithDownloadDir(dir string) Option {
+	return func(m *Manager) {
+		m.downloadDir = dir
+	}
+}
+
+// WithWarningFunc is called for every expiring torrent, in addition to the log warning.
+func WithWarningFunc(fn func(Item)) Option {
+	return func(m *Manager) {
+		m.onWarning = fn
+	}
+}
+
+func New(generalService *general.GeneralService, opts ...Option) *Manager {
+	m := &Manager{
+		general: generalService,
+		mirror:  mirror.New(generalService),
+
+		policy: PolicyWarn,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Expiring lists torrents that expire within window, soonest first. Torrents
+// that have already expired but are still listed are included.
+func (m *Manager) Expiring(window time.Duration) ([]Item, error) {
+	activeTorrents, err := m.general.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+
+	var items []Item
+	for _, t := range activeTorrents {
+		if t.ExpiresAt.IsZero() {
+			continue
+		}
+
+		remaining := t.ExpiresAt.Sub(now)
+		if remaining > window {
+			continue
+		}
+
+		items = append(items, Item{
+			Torrent:   t,
+			ExpiresAt: t.ExpiresAt.Time,
+			Remaining: remaining,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b Item) int {
+		return a.ExpiresAt.Compare(b.ExpiresAt)
+	})
+
+	return items, nil
+}
+
+// Run warns about every torrent expiring within window and applies the policy to it.
+func (m *Manager) Run(ctx context.Context, window time.Duration) ([]Result, error) {
+	items, err := m.Expiring(window)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(items))
+	var errs []error
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		log.Warn().
+			Int64("torrent_id", item.Torrent.ID).
+			Str("name", item.Torrent.Name).
+			Time("expires_at", item.ExpiresAt).
+			Dur("remaining", item.Remaining).
+			Msg("torrent is about to expire")
+
+		if m.onWarning != nil {
+			m.onWarning(item)
+		}
+
+		result := Result{
+			Item:   item,
+			Policy: m.policy,
+		}
+
+		switch m.policy {
+		case PolicyReadd:
+			result.RenewedID, result.Err = m.readd(item.Torrent)
+		case PolicyDownload:
+			result.Err = m.download(ctx, item.Torrent)
+		}
+
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s torrent %d: %w", m.policy, item.Torrent.ID, result.Err))
+		}
+
+		results = append(results, result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// readd creates the torrent again from its magnet or exported file. The
+// expiring torrent is only deleted once the new one is listed as active and
+// finished, so a queued or still downloading copy never leaves the library
+// without either.
+func (m *Manager) readd(t models.Torrent) (int64, error) {
+	name := t.Name
+	request := models.CreateTorrentRequest{
+		Name: &name,
+	}
+
+	if t.Magnet != "" {
+		torrentMagnet, err := magnet.NewMagnet(t.Magnet)
+		if err != nil {
+			return 0, err
+		}
+
+		request.Magnet = torrentMagnet
+	} else {
+		export, err := m.general.ExportTorrent(t.ID, constants.ExportFormatFile)
+		if err != nil {
+			return 0, fmt.Errorf("no stored magnet and export failed: %w", err)
+		}
+
+		request.File = export.Data
+	}
+
+	renewed, err := m.general.CreateTorrent(request)
+	if err != nil {
+		return 0, err
+	}
+
+	if renewed == nil || renewed.ID == 0 {
+		return 0, errors.New("create returned no torrent")
+	}
+
+	if renewed.ID == t.ID {
+		return renewed.ID, nil
+	}
+
+	ready, err := m.isReady(renewed.ID)
+	if err != nil {
+		return renewed.ID, fmt.Errorf("renewed as %d but failed to confirm it: %w", renewed.ID, err)
+	}
+
+	if !ready {
+		log.Info().
+			Int64("torrent_id", t.ID).
+			Int64("renewed_id", renewed.ID).
+			Msg("renewed torrent is not ready yet, keeping original")
+
+		return renewed.ID, nil
+	}
+
+	err = m.general.ControlActiveTorrent(t.ID, constants.ControlActiveOperationDelete)
+	if err != nil {
+		return renewed.ID, fmt.Errorf("renewed as %d but failed to delete original: %w", renewed.ID, err)
+	}
+
+	return renewed.ID, nil
+}
+
+// isReady reports whether the torrent with id is active and fully downloaded.
+func (m *Manager) isReady(id int64) (bool, error) {
+	activeTorrents, err := m.general.GetActiveTorrents()
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range activeTorrents {
+		if t.ID == id {
+			return t.IsDownloaded(), nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *Manager) download(ctx context.Context, t models.Torrent) error {
+	if m.downloadDir == "" {
+		return errors.New("no download directory configured")
+	}
+
+	plan, err := m.mirror.PlanTorrent(t, filepath.Clean(m.downloadDir), mirror.Options{})
+	if err != nil {
+		return err
+	}
+
+	return m.mirror.Apply(ctx, plan)
+}
@@ -0,0 +1,104 @@
+package expiry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const expiringTorrent = `{"id":1,"name":"old","magnet":"magnet:?xt=urn:btih:0123456789abcdef0123456789abcdef01234567","download_finished":true,"expires_at":"2024-01-01T12:00:00Z"}`
+
+func TestReaddPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		created   string
+		renewed   string
+		renewedID int64
+		deleted   bool
+		err       bool
+	}{
+		{
+			name:      "renewed copy is ready",
+			created:   `{"success":true,"data":{"torrent_id":2}}`,
+			renewed:   `{"id":2,"name":"old","download_finished":true}`,
+			renewedID: 2,
+			deleted:   true,
+		},
+		{
+			name:      "renewed copy is still downloading",
+			created:   `{"success":true,"data":{"torrent_id":2}}`,
+			renewed:   `{"id":2,"name":"old","download_finished":false}`,
+			renewedID: 2,
+		},
+		{
+			name:      "renewed copy is queued",
+			created:   `{"success":true,"data":{"queued_id":7}}`,
+			renewedID: 7,
+		},
+		{
+			name:    "create returns no torrent",
+			created: `{"success":true,"data":null}`,
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/" + constants.PATH_TORRENTS_CREATE:
+					w.Write([]byte(tt.created))
+				case "/" + constants.PATH_TORRENTS_CONTROL_ACTIVE:
+					var body models.ControlActiveTorrentRequest
+					json.NewDecoder(r.Body).Decode(&body)
+
+					deleted = deleted || (body.TorrentID == 1 && body.Operation == constants.ControlActiveOperationDelete)
+					w.Write([]byte(`{"success":true}`))
+				case "/" + constants.PATH_TORRENTS_GET_ACTIVE:
+					data := expiringTorrent
+					if tt.renewed != "" {
+						data += "," + tt.renewed
+					}
+
+					fmt.Fprintf(w, `{"success":true,"data":[%s]}`, data)
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			service := general.New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			manager := New(service, WithPolicy(PolicyReadd))
+			manager.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+			results, err := manager.Run(context.Background(), 24*time.Hour)
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+
+			if results[0].RenewedID != tt.renewedID {
+				t.Errorf("expected renewed ID %d, got %d", tt.renewedID, results[0].RenewedID)
+			}
+
+			if deleted != tt.deleted {
+				t.Errorf("expected original deleted %v, got %v", tt.deleted, deleted)
+			}
+		})
+	}
+}
@@ -43,5 +43,9 @@ func (s *GeneralService) ControlActiveTorrent(torrentId int64, operation constan
 		return err
 	}
 
+	if !resp.Success {
+		return responseError("control torrent", resp.BaseResponse)
+	}
+
 	return nil
 }
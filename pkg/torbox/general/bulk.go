@@ -0,0 +1,228 @@
+package general
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+// bulkConcurrency bounds the number of in-flight control requests when a bulk
+// operation has to fan out per ID. Requests still pass through the rate limiter.
+const bulkConcurrency = 4
+
+type BulkResult struct {
+	Operation string
+
+	// Results holds the outcome per ID, a nil error means the operation succeeded.
+	Results map[int64]error
+}
+
+func (r *BulkResult) Succeeded() []int64 {
+	succeeded := make([]int64, 0, len(r.Results))
+	for id, err := range r.Results {
+		if err == nil {
+			succeeded = append(succeeded, id)
+		}
+	}
+
+	slices.Sort(succeeded)
+
+	return succeeded
+}
+
+func (r *BulkResult) Failed() map[int64]error {
+	failed := make(map[int64]error)
+	for id, err := range r.Results {
+		if err != nil {
+			failed[id] = err
+		}
+	}
+
+	return failed
+}
+
+// Err joins every per-ID failure into a single error, or returns nil if all succeeded.
+func (r *BulkResult) Err() error {
+	ids := make([]int64, 0, len(r.Results))
+	for id := range r.Results {
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	var errs []error
+	for _, id := range ids {
+		if err := r.Results[id]; err != nil {
+			errs = append(errs, fmt.Errorf("%s %d: %w", r.Operation, id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// TorrentIsStalled matches torrents in any stalled state.
+func TorrentIsStalled(t models.Torrent) bool {
+	return t.DownloadState.IsStalled()
+}
+
+// TorrentOlderThan matches torrents created more than d ago.
+func TorrentOlderThan(d time.Duration) func(models.Torrent) bool {
+	return func(t models.Torrent) bool {
+		return !t.CreatedAt.IsZero() && time.Since(t.CreatedAt.Time) > d
+	}
+}
+
+func (s *GeneralService) ControlActiveTorrents(torrentIds []int64, operation constants.ControlActiveOperation) *BulkResult {
+	return s.fanOut(string(operation), torrentIds, func(id int64) error {
+		return s.ControlActiveTorrent(id, operation)
+	})
+}
+
+// ControlActiveTorrentsWhere applies operation to every active torrent matching
+// predicate. Matches are always sent by ID, even when every torrent matches, so
+// torrents added after the list call are never affected. Use
+// ControlAllActiveTorrents to act on everything.
+func (s *GeneralService) ControlActiveTorrentsWhere(predicate func(models.Torrent) bool, operation constants.ControlActiveOperation) (*BulkResult, error) {
+	activeTorrents, err := s.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for _, t := range activeTorrents {
+		if predicate(t) {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	return s.ControlActiveTorrents(ids, operation), nil
+}
+
+func (s *GeneralService) ControlAllActiveTorrents(operation constants.ControlActiveOperation) error {
+	r := models.ControlActiveTorrentRequest{
+		Operation: operation,
+		All:       true,
+	}
+
+	return s.controlAll(constants.PATH_TORRENTS_CONTROL_ACTIVE, r)
+}
+
+func (s *GeneralService) ControlQueuedTorrents(queuedIds []int64, operation constants.ControlQueuedOperation) *BulkResult {
+	return s.fanOut(string(operation), queuedIds, func(id int64) error {
+		return s.ControlQueuedTorrent(id, operation)
+	})
+}
+
+// ControlQueuedTorrentsWhere applies operation to every queued torrent matching
+// predicate, by ID.
+func (s *GeneralService) ControlQueuedTorrentsWhere(predicate func(models.QueuedDownload) bool, operation constants.ControlQueuedOperation) (*BulkResult, error) {
+	queuedTorrents, err := s.GetQueuedTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for _, q := range queuedTorrents {
+		if predicate(q) {
+			ids = append(ids, q.ID)
+		}
+	}
+
+	return s.ControlQueuedTorrents(ids, operation), nil
+}
+
+func (s *GeneralService) ControlAllQueuedTorrents(operation constants.ControlQueuedOperation) error {
+	r := models.ControlQueuedTorrentRequest{
+		Operation: operation,
+		All:       true,
+	}
+
+	return s.controlAll(constants.PATH_TORRENTS_CONTROL_QUEUED, r)
+}
+
+func (s *GeneralService) ControlUsenetDownloads(usenetIds []int64, operation constants.ControlUsenetOperation) *BulkResult {
+	return s.fanOut(string(operation), usenetIds, func(id int64) error {
+		return s.ControlUsenetDownload(id, operation)
+	})
+}
+
+func (s *GeneralService) ControlAllUsenetDownloads(operation constants.ControlUsenetOperation) error {
+	r := models.ControlUsenetRequest{
+		Operation: operation,
+		All:       true,
+	}
+
+	return s.controlAll(constants.PATH_USENET_CONTROL, r)
+}
+
+func (s *GeneralService) ControlWebDownloads(webIds []int64, operation constants.ControlWebDownloadOperation) *BulkResult {
+	return s.fanOut(string(operation), webIds, func(id int64) error {
+		return s.ControlWebDownload(id, operation)
+	})
+}
+
+func (s *GeneralService) ControlAllWebDownloads(operation constants.ControlWebDownloadOperation) error {
+	r := models.ControlWebDownloadRequest{
+		Operation: operation,
+		All:       true,
+	}
+
+	return s.controlAll(constants.PATH_WEBDL_CONTROL, r)
+}
+
+func (s *GeneralService) controlAll(path string, r any) error {
+	req, err := s.newRequest(http.MethodPost, path, &url.Values{"bodyType": {"json"}}, r)
+	if err != nil {
+		return err
+	}
+
+	var resp models.BaseResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return responseError("control all", resp)
+	}
+
+	return nil
+}
+
+func (s *GeneralService) fanOut(operation string, ids []int64, control func(id int64) error) *BulkResult {
+	result := &BulkResult{
+		Operation: operation,
+		Results:   make(map[int64]error, len(ids)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, bulkConcurrency)
+
+	for _, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(id int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := control(id)
+
+			mu.Lock()
+			result.Results[id] = err
+			mu.Unlock()
+		}(id)
+	}
+
+	wg.Wait()
+
+	return result
+}
@@ -0,0 +1,71 @@
+package general
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestControlActiveTorrentsWhere(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate func(models.Torrent) bool
+		expected  []int64
+	}{
+		{
+			name:      "every torrent matches",
+			predicate: func(models.Torrent) bool { return true },
+			expected:  []int64{1, 2},
+		},
+		{
+			name:      "some torrents match",
+			predicate: func(t models.Torrent) bool { return t.ID == 2 },
+			expected:  []int64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var controlled []int64
+			sentAll := false
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/"+constants.PATH_TORRENTS_CONTROL_ACTIVE {
+					var body models.ControlActiveTorrentRequest
+					json.NewDecoder(r.Body).Decode(&body)
+
+					mu.Lock()
+					sentAll = sentAll || body.All
+					controlled = append(controlled, body.TorrentID)
+					mu.Unlock()
+
+					w.Write([]byte(`{"success":true}`))
+					return
+				}
+
+				w.Write([]byte(`{"success":true,"data":[{"id":1},{"id":2}]}`))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			result, err := service.ControlActiveTorrentsWhere(tt.predicate, constants.ControlActiveOperationDelete)
+			if err != nil {
+				t.Fatalf("ControlActiveTorrentsWhere() error = %v", err)
+			}
+
+			slices.Sort(controlled)
+			if sentAll || !slices.Equal(controlled, tt.expected) || !slices.Equal(result.Succeeded(), tt.expected) {
+				t.Errorf("controlled %v (all=%v), want %v by ID", controlled, sentAll, tt.expected)
+			}
+		})
+	}
+}
@@ -1,20 +1,55 @@
 package general
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 )
 
-func (s *GeneralService) AuthorizeGoogleDrive(code string) error {
-	r := models.IntegrationAuthRequest{
-		Code: code,
+const defaultJobPollInterval = 5 * time.Second
+
+// Authorize links a provider to the account. The credential is the OAuth
+// authorization code or the API key, depending on the provider's auth style.
+func (s *GeneralService) Authorize(provider constants.IntegrationProvider, credential string) error {
+	info, ok := provider.Info()
+	if !ok {
+		return fmt.Errorf("%w: %q", torboxerrors.ErrUnknownIntegration, provider)
+	}
+
+	var r models.IntegrationAuthRequest
+	switch info.Auth {
+	case constants.IntegrationAuthOAuth:
+		r.Code = credential
+	case constants.IntegrationAuthAPIKey:
+		r.APIKey = credential
 	}
 
-	req, err := s.newRequest(http.MethodPost, constants.PATH_INTEGRATION_GOOGLEDRIVE, &url.Values{"bodyType": {"json"}}, r)
+	return s.authorize(info, r)
+}
+
+// AuthorizeWithPKCE links an OAuth provider using a code obtained with PKCE,
+// passing on the verifier and redirect URI needed to exchange it.
+func (s *GeneralService) AuthorizeWithPKCE(provider constants.IntegrationProvider, code string, verifier string, redirectURI string) error {
+	info, ok := provider.Info()
+	if !ok {
+		return fmt.Errorf("%w: %q", torboxerrors.ErrUnknownIntegration, provider)
+	}
+
+	return s.authorize(info, models.IntegrationAuthRequest{
+		Code:         code,
+		CodeVerifier: verifier,
+		RedirectURI:  redirectURI,
+	})
+}
+
+func (s *GeneralService) authorize(info constants.IntegrationProviderInfo, r models.IntegrationAuthRequest) error {
+	req, err := s.newRequest(http.MethodPost, info.Path, &url.Values{"bodyType": {"json"}}, r)
 	if err != nil {
 		return err
 	}
@@ -26,119 +61,202 @@ func (s *GeneralService) AuthorizeGoogleDrive(code string) error {
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to authorize Google Drive: %s", resp.Detail)
+		return responseError("authorize "+info.Name, resp)
 	}
 
 	return nil
 }
 
+// Deprecated: use Authorize with constants.IntegrationGoogleDrive.
+func (s *GeneralService) AuthorizeGoogleDrive(code string) error {
+	return s.Authorize(constants.IntegrationGoogleDrive, code)
+}
+
+// Deprecated: use Authorize with constants.IntegrationDropbox.
 func (s *GeneralService) AuthorizeDropbox(code string) error {
-	r := models.IntegrationAuthRequest{
-		Code: code,
-	}
+	return s.Authorize(constants.IntegrationDropbox, code)
+}
+
+// Deprecated: use Authorize with constants.IntegrationOneDrive.
+func (s *GeneralService) AuthorizeOneDrive(code string) error {
+	return s.Authorize(constants.IntegrationOneDrive, code)
+}
+
+// Deprecated: use Authorize with constants.IntegrationGofile.
+func (s *GeneralService) AuthorizeGofile(apiKey string) error {
+	return s.Authorize(constants.IntegrationGofile, apiKey)
+}
 
-	req, err := s.newRequest(http.MethodPost, constants.PATH_INTEGRATION_DROPBOX, &url.Values{"bodyType": {"json"}}, r)
+// Deprecated: use Authorize with constants.Integration1Fichier.
+func (s *GeneralService) Authorize1Fichier(apiKey string) error {
+	return s.Authorize(constants.Integration1Fichier, apiKey)
+}
+
+// ListIntegrations returns the link status of every registered provider,
+// plus any the API reports that the registry does not know yet.
+func (s *GeneralService) ListIntegrations() ([]models.IntegrationStatus, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_INTEGRATION_STATUS, nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var resp models.BaseResponse
+	var resp models.GetIntegrationStatusResponse
 	err = s.do(req, &resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to authorize Dropbox: %s", resp.Detail)
+		return nil, responseError("list integrations", resp.BaseResponse)
 	}
 
-	return nil
-}
+	reported := make(map[constants.IntegrationProvider]models.IntegrationStatus, len(resp.Data))
+	for _, status := range resp.Data {
+		reported[status.Provider] = status
+	}
 
-func (s *GeneralService) AuthorizeOneDrive(code string) error {
-	r := models.IntegrationAuthRequest{
-		Code: code,
+	var statuses []models.IntegrationStatus
+	for _, info := range constants.IntegrationProviders() {
+		status := reported[info.Provider]
+		status.Provider = info.Provider
+		status.Info = info
+
+		statuses = append(statuses, status)
+		delete(reported, info.Provider)
+	}
+
+	for _, status := range resp.Data {
+		if _, ok := reported[status.Provider]; !ok {
+			continue
+		}
+
+		status.Info = constants.IntegrationProviderInfo{
+			Provider: status.Provider,
+			Name:     string(status.Provider),
+		}
+
+		statuses = append(statuses, status)
 	}
 
-	req, err := s.newRequest(http.MethodPost, constants.PATH_INTEGRATION_ONEDRIVE, &url.Values{"bodyType": {"json"}}, r)
+	return statuses, nil
+}
+
+func (s *GeneralService) GetIntegrationJobs() ([]models.IntegrationJob, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_INTEGRATION_JOBS, nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var resp models.BaseResponse
+	var resp models.GetIntegrationJobsResponse
 	err = s.do(req, &resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to authorize OneDrive: %s", resp.Detail)
+		return nil, responseError("get integration jobs", resp.BaseResponse)
 	}
 
-	return nil
+	return resp.Data, nil
 }
 
-func (s *GeneralService) AuthorizeGofile(apiKey string) error {
-	r := models.IntegrationAuthRequest{
-		APIKey: apiKey,
+// QueueIntegrationTransfer asks TorBox to upload a finished torrent, usenet
+// or web download to an authorized provider.
+func (s *GeneralService) QueueIntegrationTransfer(provider constants.IntegrationProvider, r models.IntegrationTransferRequest) (*models.IntegrationJob, error) {
+	info, ok := provider.Info()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", torboxerrors.ErrUnknownIntegration, provider)
 	}
 
-	req, err := s.newRequest(http.MethodPost, constants.PATH_INTEGRATION_GOFILE, &url.Values{"bodyType": {"json"}}, r)
+	req, err := s.newRequest(http.MethodPost, info.Path, &url.Values{"bodyType": {"json"}}, r)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var resp models.BaseResponse
+	var resp models.GetIntegrationJobResponse
 	err = s.do(req, &resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to authorize Gofile: %s", resp.Detail)
+		return nil, responseError("queue "+info.Name+" transfer", resp.BaseResponse)
 	}
 
-	return nil
+	return &resp.Data, nil
 }
 
-func (s *GeneralService) Authorize1Fichier(apiKey string) error {
-	r := models.IntegrationAuthRequest{
-		APIKey: apiKey,
-	}
+func (s *GeneralService) GetIntegrationJob(jobId int64) (*models.IntegrationJob, error) {
+	reqPath := fmt.Sprintf("%s/%d", constants.PATH_INTEGRATION_JOB, jobId)
 
-	req, err := s.newRequest(http.MethodPost, constants.PATH_INTEGRATION_1FICHIER, &url.Values{"bodyType": {"json"}}, r)
+	req, err := s.newRequest(http.MethodGet, reqPath, nil, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var resp models.BaseResponse
+	var resp models.GetIntegrationJobResponse
 	err = s.do(req, &resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to authorize 1Fichier: %s", resp.Detail)
+		return nil, responseError("get integration job", resp.BaseResponse)
 	}
 
-	return nil
+	return &resp.Data, nil
 }
 
-func (s *GeneralService) GetIntegrationJobs() ([]models.IntegrationJob, error) {
-	req, err := s.newRequest(http.MethodGet, constants.PATH_INTEGRATION_JOBS, nil, nil)
+func (s *GeneralService) CancelIntegrationJob(jobId int64) error {
+	reqPath := fmt.Sprintf("%s/%d", constants.PATH_INTEGRATION_JOB, jobId)
+
+	req, err := s.newRequest(http.MethodDelete, reqPath, nil, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var resp models.GetIntegrationJobsResponse
+	var resp models.BaseResponse
 	err = s.do(req, &resp)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get integration jobs: %s", resp.Detail)
+		return responseError("cancel integration job", resp)
 	}
 
-	return resp.Data, nil
+	return nil
+}
+
+// WaitForJob polls a job every interval until it completes, fails or is
+// cancelled, or ctx is done. An interval of zero or less polls every
+// defaultJobPollInterval. A failed or cancelled job is returned along with an
+// error wrapping ErrIntegrationJobFailed.
+func (s *GeneralService) WaitForJob(ctx context.Context, jobId int64, interval time.Duration) (*models.IntegrationJob, error) {
+	if interval <= 0 {
+		interval = defaultJobPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := s.GetIntegrationJob(jobId)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.Status {
+		case constants.IntegrationJobCompleted:
+			return job, nil
+		case constants.IntegrationJobFailed, constants.IntegrationJobCancelled:
+			return job, fmt.Errorf("integration job %d %s: %s: %w", jobId, job.Status, job.Detail, torboxerrors.ErrIntegrationJobFailed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
@@ -0,0 +1,243 @@
+package general
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestQueueIntegrationTransfer(t *testing.T) {
+	var got models.IntegrationTransferRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+constants.PATH_INTEGRATION_DROPBOX {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"success":true,"data":{"job_id":99,"status":"pending"}}`))
+	}))
+	defer server.Close()
+
+	service := New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	fileId := int64(3)
+	job, err := service.QueueIntegrationTransfer(constants.IntegrationDropbox, models.IntegrationTransferRequest{
+		ID:     12,
+		Type:   constants.IntegrationItemTorrent,
+		FileID: &fileId,
+	})
+	if err != nil {
+		t.Fatalf("QueueIntegrationTransfer() error = %v", err)
+	}
+
+	if job.ID != 99 || job.Status != constants.IntegrationJobPending {
+		t.Errorf("unexpected job %+v", job)
+	}
+
+	if got.ID != 12 || got.Type != constants.IntegrationItemTorrent || got.FileID == nil || *got.FileID != 3 {
+		t.Errorf("unexpected request %+v", got)
+	}
+}
+
+func TestWaitForJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		statuses []constants.IntegrationJobStatus
+		wantErr  error
+	}{
+		{
+			name:     "completes",
+			interval: time.Millisecond,
+			statuses: []constants.IntegrationJobStatus{constants.IntegrationJobPending, constants.IntegrationJobUploading, constants.IntegrationJobCompleted},
+		},
+		{
+			name:     "fails",
+			interval: time.Millisecond,
+			statuses: []constants.IntegrationJobStatus{constants.IntegrationJobUploading, constants.IntegrationJobFailed},
+			wantErr:  torboxerrors.ErrIntegrationJobFailed,
+		},
+		{
+			name:     "zero interval uses the default",
+			statuses: []constants.IntegrationJobStatus{constants.IntegrationJobCompleted},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/"+constants.PATH_INTEGRATION_JOB+"/7" {
+					http.NotFound(w, r)
+					return
+				}
+
+				i := min(int(polls.Add(1))-1, len(tt.statuses)-1)
+				fmt.Fprintf(w, `{"success":true,"data":{"id":7,"status":%q}}`, tt.statuses[i])
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			job, err := service.WaitForJob(context.Background(), 7, tt.interval)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WaitForJob() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if job.Status != tt.statuses[len(tt.statuses)-1] || int(polls.Load()) != len(tt.statuses) {
+				t.Errorf("job = %+v after %d polls", job, polls.Load())
+			}
+		})
+	}
+}
+
+func TestListIntegrations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+constants.PATH_INTEGRATION_STATUS {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`{"success":true,"data":[{"provider":"dropbox","authorized":true,"account":"me@example.com"},{"provider":"mega","authorized":true}]}`))
+	}))
+	defer server.Close()
+
+	service := New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	statuses, err := service.ListIntegrations()
+	if err != nil {
+		t.Fatalf("ListIntegrations() error = %v", err)
+	}
+
+	registered := constants.IntegrationProviders()
+	if len(statuses) != len(registered)+1 {
+		t.Fatalf("expected %d statuses, got %d", len(registered)+1, len(statuses))
+	}
+
+	for i, info := range registered {
+		status := statuses[i]
+		if status.Provider != info.Provider || status.Info.Name != info.Name {
+			t.Errorf("status %d = %+v, want provider %s", i, status, info.Provider)
+		}
+
+		authorized := info.Provider == constants.IntegrationDropbox
+		if status.Authorized != authorized {
+			t.Errorf("%s authorized = %t, want %t", info.Provider, status.Authorized, authorized)
+		}
+	}
+
+	unknown := statuses[len(statuses)-1]
+	if unknown.Provider != "mega" || !unknown.Authorized || unknown.Info.Provider != "mega" || unknown.Info.Name != "mega" {
+		t.Errorf("unexpected unknown provider status %+v", unknown)
+	}
+}
+
+func TestCancelIntegrationJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{
+			name: "cancelled",
+			body: `{"success":true}`,
+		},
+		{
+			name:    "unknown job",
+			body:    `{"success":false,"error":"ITEM_NOT_FOUND","detail":"no such job"}`,
+			wantErr: torboxerrors.ErrDownloadNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/"+constants.PATH_INTEGRATION_JOB+"/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			err := service.CancelIntegrationJob(7)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CancelIntegrationJob() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider constants.IntegrationProvider
+		path     string
+		expected models.IntegrationAuthRequest
+		wantErr  error
+	}{
+		{
+			name:     "oauth code",
+			provider: constants.IntegrationOneDrive,
+			path:     constants.PATH_INTEGRATION_ONEDRIVE,
+			expected: models.IntegrationAuthRequest{Code: "credential"},
+		},
+		{
+			name:     "api key",
+			provider: constants.IntegrationGofile,
+			path:     constants.PATH_INTEGRATION_GOFILE,
+			expected: models.IntegrationAuthRequest{APIKey: "credential"},
+		},
+		{
+			name:     "unknown provider",
+			provider: "mega",
+			wantErr:  torboxerrors.ErrUnknownIntegration,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got models.IntegrationAuthRequest
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				json.NewDecoder(r.Body).Decode(&got)
+				w.Write([]byte(`{"success":true}`))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			err := service.Authorize(tt.provider, "credential")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if path != "/"+tt.path || got != tt.expected {
+				t.Errorf("sent %+v to %s, want %+v to /%s", got, path, tt.expected, tt.path)
+			}
+		})
+	}
+}
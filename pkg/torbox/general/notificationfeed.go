@@ -0,0 +1,203 @@
+package general
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const (
+	notificationFeedTitle = "TorBox Notifications"
+	notificationFeedLink  = "https://torbox.app/notifications"
+	notificationGUIDBase  = "urn:torbox:notification:"
+)
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title    string        `xml:"title"`
+	ID       string        `xml:"id"`
+	Link     atomLink      `xml:"link"`
+	Updated  string        `xml:"updated"`
+	Category *atomCategory `xml:"category,omitempty"`
+	Summary  string        `xml:"summary"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// WriteNotificationFeed writes the account's notifications that match filter
+// to w as an RSS 2.0 or Atom feed, for feed readers that can't authenticate
+// against the API.
+func (s *GeneralService) WriteNotificationFeed(w io.Writer, format constants.FeedFormat, filter models.NotificationFilter) error {
+	notifications, err := s.GetNotifications()
+	if err != nil {
+		return err
+	}
+
+	var matched []models.Notification
+	for _, n := range notifications {
+		if filter.Match(n) {
+			matched = append(matched, n)
+		}
+	}
+
+	return EncodeNotificationFeed(w, format, matched)
+}
+
+// EncodeNotificationFeed writes notifications to w as a feed. The feed's
+// updated time is that of the newest notification.
+func EncodeNotificationFeed(w io.Writer, format constants.FeedFormat, notifications []models.Notification) error {
+	var updated time.Time
+	for _, n := range notifications {
+		if n.CreatedAt.After(updated) {
+			updated = n.CreatedAt.Time
+		}
+	}
+
+	var document any
+	switch format {
+	case constants.FeedFormatRSS:
+		document = rssFeed(notifications, updated)
+	case constants.FeedFormatAtom:
+		document = atomFeed(notifications, updated)
+	default:
+		return fmt.Errorf("unsupported feed format %q", format)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	err = encoder.Encode(document)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
+
+func rssFeed(notifications []models.Notification, updated time.Time) rssDocument {
+	document := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       notificationFeedTitle,
+			Link:        notificationFeedLink,
+			Description: "Notifications from your TorBox account",
+		},
+	}
+
+	if !updated.IsZero() {
+		document.Channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, n := range notifications {
+		item := rssItem{
+			Title:       n.Title,
+			Link:        notificationFeedLink,
+			GUID:        rssGUID{Value: notificationGUID(n)},
+			Category:    string(n.Type),
+			Description: n.Message,
+		}
+
+		if !n.CreatedAt.IsZero() {
+			item.PubDate = n.CreatedAt.UTC().Format(time.RFC1123Z)
+		}
+
+		document.Channel.Items = append(document.Channel.Items, item)
+	}
+
+	return document
+}
+
+func atomFeed(notifications []models.Notification, updated time.Time) atomDocument {
+	// Atom requires an updated time on the feed and every entry
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	document := atomDocument{
+		Title:   notificationFeedTitle,
+		ID:      notificationFeedLink,
+		Link:    atomLink{Href: notificationFeedLink},
+		Updated: updated.UTC().Format(time.RFC3339),
+	}
+
+	for _, n := range notifications {
+		entryUpdated := updated
+		if !n.CreatedAt.IsZero() {
+			entryUpdated = n.CreatedAt.Time
+		}
+
+		entry := atomEntry{
+			Title:   n.Title,
+			ID:      notificationGUID(n),
+			Link:    atomLink{Href: notificationFeedLink},
+			Updated: entryUpdated.UTC().Format(time.RFC3339),
+			Summary: n.Message,
+		}
+
+		if n.Type != "" {
+			entry.Category = &atomCategory{Term: string(n.Type)}
+		}
+
+		document.Entries = append(document.Entries, entry)
+	}
+
+	return document
+}
+
+func notificationGUID(n models.Notification) string {
+	return notificationGUIDBase + strconv.FormatInt(n.ID, 10)
+}
@@ -1,6 +1,9 @@
 package general
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"net/http"
 
@@ -8,23 +11,37 @@ import (
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 )
 
-func (s *GeneralService) GetRSSNotifications() ([]models.Notification, error) {
+// GetRSSNotifications reads the notifications RSS endpoint, which serves an
+// XML feed rather than the usual JSON envelope.
+func (s *GeneralService) GetRSSNotifications() (*models.NotificationFeed, error) {
 	req, err := s.newRequest(http.MethodGet, constants.PATH_NOTIFICATIONS_RSS, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp models.GetNotificationsResponse
-	err = s.do(req, &resp)
+	req.Header.Set("Accept", "application/rss+xml, application/xml")
+
+	data, err := s.doRaw(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if !resp.Success {
-		return nil, fmt.Errorf("failed to get RSS notifications: %s", resp.Detail)
+	// errors are still reported in the JSON envelope
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var resp models.BaseResponse
+		err = json.Unmarshal(data, &resp)
+		if err == nil && !resp.Success {
+			return nil, responseError("get RSS notifications", resp)
+		}
 	}
 
-	return resp.Data, nil
+	var feed models.NotificationFeed
+	err = xml.Unmarshal(data, &feed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS notifications: %w", err)
+	}
+
+	return &feed, nil
 }
 
 func (s *GeneralService) GetNotifications() ([]models.Notification, error) {
@@ -40,7 +57,7 @@ func (s *GeneralService) GetNotifications() ([]models.Notification, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get notifications: %s", resp.Detail)
+		return nil, responseError("get notifications", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -59,7 +76,59 @@ func (s *GeneralService) ClearNotifications() error {
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to clear notifications: %s", resp.Detail)
+		return responseError("clear notifications", resp)
+	}
+
+	return nil
+}
+
+// ClearNotification removes a single notification by ID.
+func (s *GeneralService) ClearNotification(notificationId int64) error {
+	return s.notificationAction(constants.PATH_NOTIFICATIONS_CLEAR, notificationId, "clear notification")
+}
+
+// MarkNotificationRead marks a single notification as read without removing it.
+func (s *GeneralService) MarkNotificationRead(notificationId int64) error {
+	return s.notificationAction(constants.PATH_NOTIFICATIONS_READ, notificationId, "mark notification read")
+}
+
+// SendTestNotification asks TorBox to send a test notification to every
+// channel configured on the account.
+func (s *GeneralService) SendTestNotification() error {
+	req, err := s.newRequest(http.MethodPost, constants.PATH_NOTIFICATIONS_TEST, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var resp models.BaseResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return responseError("send test notification", resp)
+	}
+
+	return nil
+}
+
+func (s *GeneralService) notificationAction(path string, notificationId int64, action string) error {
+	reqPath := fmt.Sprintf("%s/%d", path, notificationId)
+
+	req, err := s.newRequest(http.MethodPost, reqPath, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	var resp models.BaseResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return err
+	}
+
+	if !resp.Success {
+		return responseError(action, resp)
 	}
 
 	return nil
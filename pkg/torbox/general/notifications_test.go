@@ -0,0 +1,190 @@
+package general
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const notificationsRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>TorBox Notifications</title>
+    <link>https://torbox.app</link>
+    <description>Your notifications</description>
+    <item>
+      <title>Download Ready</title>
+      <link>https://torbox.app/downloads</link>
+      <guid>42</guid>
+      <pubDate>Wed, 01 May 2024 10:00:00 +0000</pubDate>
+      <category>download_ready</category>
+      <description>ubuntu.iso has finished downloading</description>
+    </item>
+  </channel>
+</rss>`
+
+func TestGetRSSNotifications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+constants.PATH_NOTIFICATIONS_RSS {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(notificationsRSS))
+	}))
+	defer server.Close()
+
+	service := New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	feed, err := service.GetRSSNotifications()
+	if err != nil {
+		t.Fatalf("GetRSSNotifications() error = %v", err)
+	}
+
+	items := feed.Items()
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+
+	item := items[0]
+	if item.Title != "Download Ready" || item.GUID != "42" || item.Category != "download_ready" {
+		t.Errorf("unexpected item %+v", item)
+	}
+
+	expected := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if !item.PubDate.Equal(expected) {
+		t.Errorf("PubDate = %v, want %v", item.PubDate.Time, expected)
+	}
+}
+
+func TestEncodeNotificationFeed(t *testing.T) {
+	notifications := []models.Notification{
+		{ID: 1, Type: "download_ready", Title: "Ready", Message: "done", CreatedAt: models.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))},
+		{ID: 2, Type: "download_failed", Title: "Failed", Message: "oops"},
+	}
+
+	tests := []struct {
+		name   string
+		format constants.FeedFormat
+		check  func(t *testing.T, data []byte)
+	}{
+		{
+			name:   "rss",
+			format: constants.FeedFormatRSS,
+			check: func(t *testing.T, data []byte) {
+				var feed models.NotificationFeed
+				err := xml.Unmarshal(data, &feed)
+				if err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+
+				items := feed.Items()
+				if len(items) != 2 {
+					t.Fatalf("expected 2 items, got %d", len(items))
+				}
+
+				if items[0].GUID != "urn:torbox:notification:1" || !items[0].PubDate.Equal(notifications[0].CreatedAt.Time) {
+					t.Errorf("unexpected first item %+v", items[0])
+				}
+			},
+		},
+		{
+			name:   "atom",
+			format: constants.FeedFormatAtom,
+			check: func(t *testing.T, data []byte) {
+				if !bytes.Contains(data, []byte(`xmlns="http://www.w3.org/2005/Atom"`)) {
+					t.Errorf("missing Atom namespace in %s", data)
+				}
+
+				if strings.Count(string(data), "<entry>") != 2 {
+					t.Errorf("expected 2 entries in %s", data)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := EncodeNotificationFeed(&buf, tt.format, notifications)
+			if err != nil {
+				t.Fatalf("EncodeNotificationFeed() error = %v", err)
+			}
+
+			tt.check(t, buf.Bytes())
+		})
+	}
+}
+
+func TestNotificationActions(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(s *GeneralService) error
+		expectedPath string
+	}{
+		{
+			name:         "clear",
+			call:         func(s *GeneralService) error { return s.ClearNotification(42) },
+			expectedPath: constants.PATH_NOTIFICATIONS_CLEAR + "/42",
+		},
+		{
+			name:         "read",
+			call:         func(s *GeneralService) error { return s.MarkNotificationRead(42) },
+			expectedPath: constants.PATH_NOTIFICATIONS_READ + "/42",
+		},
+		{
+			name:         "test",
+			call:         func(s *GeneralService) error { return s.SendTestNotification() },
+			expectedPath: constants.PATH_NOTIFICATIONS_TEST,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, success := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s success %t", tt.name, success), func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodPost || r.URL.Path != "/"+tt.expectedPath {
+						t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					}
+
+					if success {
+						w.Write([]byte(`{"success":true}`))
+						return
+					}
+
+					w.Write([]byte(`{"success":false,"error":"ITEM_NOT_FOUND","detail":"no such notification"}`))
+				}))
+				defer server.Close()
+
+				service := New(http.Client{}, "token")
+				service.BaseURL = server.URL
+
+				err := tt.call(service)
+				if success {
+					if err != nil {
+						t.Errorf("unexpected error: %v", err)
+					}
+
+					return
+				}
+
+				var apiErr *torboxerrors.APIError
+				if !errors.As(err, &apiErr) || !errors.Is(err, torboxerrors.ErrDownloadNotFound) {
+					t.Errorf("error = %v, want an APIError matching ErrDownloadNotFound", err)
+				}
+			})
+		}
+	}
+}
@@ -43,5 +43,9 @@ func (s *GeneralService) ControlQueuedTorrent(queuedId int64, operation constant
 		return err
 	}
 
+	if !resp.Success {
+		return responseError("control queued torrent", resp.BaseResponse)
+	}
+
 	return nil
 }
@@ -9,7 +9,55 @@ import (
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 )
 
+// GetRSSFeeds lists the account's feeds along with their rules.
+func (s *GeneralService) GetRSSFeeds() ([]models.RSSFeed, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_RSS_GET, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetRSSFeedsResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get RSS feeds", resp.BaseResponse)
+	}
+
+	return resp.Data, nil
+}
+
+// GetRSSFeedItems lists the items TorBox has read from a feed.
+func (s *GeneralService) GetRSSFeedItems(rssFeedId int64) ([]models.RSSFeedItem, error) {
+	params := &url.Values{}
+	params.Add("rss_feed_id", fmt.Sprintf("%d", rssFeedId))
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_RSS_ITEMS, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetRSSFeedItemsResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get RSS feed items", resp.BaseResponse)
+	}
+
+	return resp.Data, nil
+}
+
 func (s *GeneralService) AddRSS(r models.AddRSSRequest) (*models.RSSFeed, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := s.newRequest(http.MethodPost, constants.PATH_RSS_ADD, &url.Values{"bodyType": {"json"}}, r)
 	if err != nil {
 		return nil, err
@@ -22,7 +70,7 @@ func (s *GeneralService) AddRSS(r models.AddRSSRequest) (*models.RSSFeed, error)
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to add RSS: %s", resp.Detail)
+		return nil, responseError("add RSS", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -46,13 +94,18 @@ func (s *GeneralService) ControlRSS(rssId int64, operation constants.ControlRSSO
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to control RSS: %s", resp.Detail)
+		return responseError("control RSS", resp)
 	}
 
 	return nil
 }
 
 func (s *GeneralService) ModifyRSS(r models.ModifyRSSRequest) (*models.RSSFeed, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := s.newRequest(http.MethodPost, constants.PATH_RSS_MODIFY, &url.Values{"bodyType": {"json"}}, r)
 	if err != nil {
 		return nil, err
@@ -65,7 +118,7 @@ func (s *GeneralService) ModifyRSS(r models.ModifyRSSRequest) (*models.RSSFeed,
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to modify RSS: %s", resp.Detail)
+		return nil, responseError("modify RSS", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
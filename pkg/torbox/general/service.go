@@ -9,9 +9,12 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/dylanmazurek/go-torbox/internal/ratelimit"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 	"github.com/perimeterx/marshmallow"
 	"github.com/rs/zerolog/log"
@@ -22,15 +25,36 @@ type GeneralService struct {
 	Token   string
 
 	internalClient *http.Client
+	limiter        *ratelimit.Limiter
+
+	hostersMu      sync.Mutex
+	hosters        []models.Hoster
+	hostersFetched time.Time
+}
+
+type Option func(*GeneralService)
+
+// WithLimiter makes every request wait on limiter, so the service can share a
+// rate budget with other services. Without it requests are not limited.
+func WithLimiter(limiter *ratelimit.Limiter) Option {
+	return func(s *GeneralService) {
+		s.limiter = limiter
+	}
 }
 
-func New(internalClient http.Client, token string) *GeneralService {
-	return &GeneralService{
+func New(internalClient http.Client, token string, opts ...Option) *GeneralService {
+	s := &GeneralService{
 		BaseURL: constants.API_GENERAL_BASE_URL,
 		Token:   token,
 
 		internalClient: &internalClient,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *GeneralService) newRequest(method string, reqPath string, urlParams *url.Values, body any) (*http.Request, error) {
@@ -103,6 +127,40 @@ func (s *GeneralService) do(req *http.Request, obj any) error {
 }
 
 func (s *GeneralService) doWithRetry(req *http.Request, obj any, maxRetries int) error {
+	return s.doWithDecoder(req, maxRetries, func(bodyBytes []byte) error {
+		unknownFields, err := marshmallow.Unmarshal(bodyBytes, obj, marshmallow.WithExcludeKnownFieldsFromMap(true))
+		if err != nil {
+			return err
+		}
+
+		if len(unknownFields) > 0 {
+			log.Warn().Fields(unknownFields).
+				Msgf("unknown fields in torbox response")
+		}
+
+		return nil
+	})
+}
+
+// doRaw returns the response body as-is, for endpoints that serve files or XML
+// rather than the JSON BaseResponse envelope.
+func (s *GeneralService) doRaw(req *http.Request) ([]byte, error) {
+	var body []byte
+	err := s.doWithDecoder(req, 3, func(bodyBytes []byte) error {
+		body = bodyBytes
+		return nil
+	})
+
+	return body, err
+}
+
+// responseError turns a response with success set to false into a typed
+// APIError, so callers can match it with errors.Is.
+func responseError(action string, resp models.BaseResponse) error {
+	return fmt.Errorf("failed to %s: %w", action, torboxerrors.NewAPIError(0, resp.Error, resp.Detail))
+}
+
+func (s *GeneralService) doWithDecoder(req *http.Request, maxRetries int, decode func(bodyBytes []byte) error) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
@@ -116,7 +174,14 @@ func (s *GeneralService) doWithRetry(req *http.Request, obj any, maxRetries int)
 			time.Sleep(delay)
 		}
 
-		req.Header.Add("Accept", "application/json")
+		err := s.limiter.Wait(req.Context())
+		if err != nil {
+			return err
+		}
+
+		if req.Header.Get("Accept") == "" {
+			req.Header.Set("Accept", "application/json")
+		}
 
 		httpResponse, err := s.internalClient.Do(req)
 		if err != nil {
@@ -184,16 +249,7 @@ func (s *GeneralService) doWithRetry(req *http.Request, obj any, maxRetries int)
 				continue
 			}
 
-			// Create detailed error message from API response
-			if errResp.Error != "" && errResp.Detail != "" {
-				lastErr = fmt.Errorf("torbox API error: %s - %s (status: %d)", errResp.Error, errResp.Detail, httpResponse.StatusCode)
-			} else if errResp.Error != "" {
-				lastErr = fmt.Errorf("torbox API error: %s (status: %d)", errResp.Error, httpResponse.StatusCode)
-			} else if errResp.Detail != "" {
-				lastErr = fmt.Errorf("torbox API error: %s (status: %d)", errResp.Detail, httpResponse.StatusCode)
-			} else {
-				lastErr = fmt.Errorf("torbox server error (status: %d)", httpResponse.StatusCode)
-			}
+			lastErr = torboxerrors.NewAPIError(httpResponse.StatusCode, errResp.Error, errResp.Detail)
 			return lastErr
 		}
 
@@ -213,7 +269,7 @@ func (s *GeneralService) doWithRetry(req *http.Request, obj any, maxRetries int)
 				return err
 			}
 
-			unknownFields, err := marshmallow.Unmarshal(bodyBytes, obj, marshmallow.WithExcludeKnownFieldsFromMap(true))
+			err = decode(bodyBytes)
 			if err != nil {
 				lastErr = err
 				if attempt < maxRetries {
@@ -225,11 +281,6 @@ func (s *GeneralService) doWithRetry(req *http.Request, obj any, maxRetries int)
 				}
 				return err
 			}
-
-			if len(unknownFields) > 0 {
-				log.Warn().Fields(unknownFields).
-					Msgf("unknown fields in torbox response")
-			}
 		}
 
 		// Success - return without error
@@ -0,0 +1,69 @@
+package general
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+)
+
+func TestUnsuccessfulResponses(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(s *GeneralService) error
+		code     string
+		expected error
+	}{
+		{
+			name: "check cached",
+			call: func(s *GeneralService) error {
+				_, err := s.CheckCached("abc")
+				return err
+			},
+			code:     string(constants.APIErrorBadToken),
+			expected: torboxerrors.ErrUnauthorized,
+		},
+		{
+			name: "control torrent",
+			call: func(s *GeneralService) error {
+				return s.ControlActiveTorrent(1, constants.ControlActiveOperationPause)
+			},
+			code:     string(constants.APIErrorItemNotFound),
+			expected: torboxerrors.ErrDownloadNotFound,
+		},
+		{
+			name: "get download url",
+			call: func(s *GeneralService) error {
+				_, err := s.GetDownloadUrl(1, 2)
+				return err
+			},
+			code:     string(constants.APIErrorItemNotFound),
+			expected: torboxerrors.ErrDownloadNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"success":false,"error":"` + tt.code + `","detail":"rejected"}`))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			err := tt.call(service)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("error = %v, want %v", err, tt.expected)
+			}
+
+			var apiErr *torboxerrors.APIError
+			if !errors.As(err, &apiErr) || apiErr.Detail != "rejected" {
+				t.Errorf("error = %v, want an APIError with the detail", err)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package general
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
@@ -21,7 +20,7 @@ func (s *GeneralService) GetStats() (*models.Stats, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get stats: %s", resp.Detail)
+		return nil, responseError("get stats", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -2,14 +2,18 @@ package general
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"slices"
 
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/dylanmazurek/go-torbox/pkg/torrent"
 )
 
 func (s *GeneralService) CreateTorrent(r models.CreateTorrentRequest) (*models.Torrent, error) {
@@ -26,6 +30,10 @@ func (s *GeneralService) CreateTorrent(r models.CreateTorrentRequest) (*models.T
 			return nil, err
 		}
 
+		for key, values := range createTorrentOptions(r) {
+			writer.WriteField(key, values[0])
+		}
+
 		writer.Close()
 		params.Set("bodyType", "file")
 		params.Set("Content-Type", writer.FormDataContentType())
@@ -34,13 +42,8 @@ func (s *GeneralService) CreateTorrent(r models.CreateTorrentRequest) (*models.T
 	} else if r.Magnet != nil {
 		body := &bytes.Buffer{}
 
-		form := url.Values{}
+		form := createTorrentOptions(r)
 		form.Set("magnet", *r.Magnet.GetUrl())
-		form.Set("name", *r.Name)
-
-		if r.AsQueued != nil {
-			form.Set("as_queued", fmt.Sprintf("%t", *r.AsQueued))
-		}
 
 		_, err := body.WriteString(form.Encode())
 		if err != nil {
@@ -65,12 +68,35 @@ func (s *GeneralService) CreateTorrent(r models.CreateTorrentRequest) (*models.T
 	}
 
 	if !resp.Success {
-		return nil, err
+		return nil, responseError("create torrent", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
 }
 
+// createTorrentOptions encodes the optional settings shared by magnet and file creates.
+func createTorrentOptions(r models.CreateTorrentRequest) url.Values {
+	form := url.Values{}
+
+	if r.Name != nil {
+		form.Set("name", *r.Name)
+	}
+
+	if r.Seed != nil {
+		form.Set("seed", fmt.Sprintf("%d", *r.Seed))
+	}
+
+	if r.AllowZip != nil {
+		form.Set("allow_zip", fmt.Sprintf("%t", *r.AllowZip))
+	}
+
+	if r.AsQueued != nil {
+		form.Set("as_queued", fmt.Sprintf("%t", *r.AsQueued))
+	}
+
+	return form
+}
+
 func (s *GeneralService) GetDownloadUrl(torrentId int64, fileId int64) (*string, error) {
 	params := &url.Values{}
 	params.Add("torrent_id", fmt.Sprintf("%d", torrentId))
@@ -89,20 +115,15 @@ func (s *GeneralService) GetDownloadUrl(torrentId int64, fileId int64) (*string,
 	}
 
 	if !resp.Success {
-		if resp.Error != "" && resp.Detail != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s - %s", resp.Error, resp.Detail)
-		} else if resp.Error != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s", resp.Error)
-		} else if resp.Detail != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s", resp.Detail)
-		} else {
-			return nil, fmt.Errorf("failed to get download URL: unknown error")
-		}
+		return nil, responseError("get download URL", resp.BaseResponse)
 	}
 
 	return &resp.DownloadUrl, nil
 }
 
+// Deprecated: ControlAnyTorrent takes an untyped operation and lists both
+// active and queued torrents to locate the ID. Use library.Library.Get and the
+// Download control methods instead.
 func (s *GeneralService) ControlAnyTorrent(id int64, operation string) error {
 	activeTorrents, err := s.GetActiveTorrents()
 	if err != nil {
@@ -137,6 +158,8 @@ func (s *GeneralService) ControlAnyTorrent(id int64, operation string) error {
 	return fmt.Errorf("torrent with ID %d is neither active nor queued", id)
 }
 
+// CheckCached returns the cache entry for hash, or nil when the torrent is
+// not cached.
 func (s *GeneralService) CheckCached(hash string) (*models.CacheCheckResponse, error) {
 	params := &url.Values{}
 	params.Add("hash", hash)
@@ -147,41 +170,111 @@ func (s *GeneralService) CheckCached(hash string) (*models.CacheCheckResponse, e
 		return nil, err
 	}
 
-	var resp models.CheckCachedResponse
+	var resp models.CheckCachedListResponse
 	err = s.do(req, &resp)
 	if err != nil {
 		return nil, err
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to check cache: %s", resp.Detail)
+		return nil, responseError("check cache", resp.BaseResponse)
 	}
 
-	return resp.Data, nil
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+
+	return &resp.Data[0], nil
 }
 
+// Deprecated: GetTorrentInfo only looks up a hash. Use PreviewTorrent, which
+// also accepts a magnet or .torrent file and a timeout.
 func (s *GeneralService) GetTorrentInfo(hash string) (*models.Torrent, error) {
-	params := &url.Values{}
-	params.Add("hash", hash)
+	return s.PreviewTorrent(models.TorrentInfoRequest{
+		Hash: hash,
+	})
+}
+
+// PreviewTorrent fetches the name, size and file list of a torrent without
+// adding it, so files can be reviewed before calling CreateTorrent. The
+// torrentinfo POST form only takes a magnet or .torrent file, so hashes are
+// looked up with a GET carrying the same fields as query parameters.
+func (s *GeneralService) PreviewTorrent(r models.TorrentInfoRequest) (*models.Torrent, error) {
+	form := url.Values{}
+	if r.Timeout > 0 {
+		form.Set("timeout", fmt.Sprintf("%d", int64(r.Timeout.Seconds())))
+	}
+
+	if r.Hash != "" {
+		form.Set("hash", r.Hash)
+
+		req, err := s.newRequest(http.MethodGet, constants.PATH_TORRENTS_INFO, &form, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.torrentInfo(req)
+	}
+
+	var params = &url.Values{}
+	var reqBody *bytes.Buffer
+
+	switch {
+	case r.Magnet != nil:
+		form.Set("magnet", *r.Magnet.GetUrl())
+
+		params.Set("bodyType", "form")
+		reqBody = bytes.NewBufferString(form.Encode())
+	case r.File != nil:
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("torrent_file", "torrent.torrent")
+		if err != nil {
+			return nil, err
+		}
 
-	req, err := s.newRequest(http.MethodGet, constants.PATH_TORRENTS_INFO, params, nil)
+		_, err = part.Write(r.File)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range form {
+			writer.WriteField(key, values[0])
+		}
+
+		writer.Close()
+		params.Set("bodyType", "file")
+		params.Set("Content-Type", writer.FormDataContentType())
+
+		reqBody = body
+	default:
+		return nil, torboxerrors.ErrNoTorrentSource
+	}
+
+	req, err := s.newRequest(http.MethodPost, constants.PATH_TORRENTS_INFO, params, reqBody)
 	if err != nil {
 		return nil, err
 	}
 
+	return s.torrentInfo(req)
+}
+
+func (s *GeneralService) torrentInfo(req *http.Request) (*models.Torrent, error) {
 	var resp models.TorrentInfoResponse
-	err = s.do(req, &resp)
+	err := s.do(req, &resp)
 	if err != nil {
 		return nil, err
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get torrent info: %s", resp.Detail)
+		return nil, responseError("get torrent info", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
 }
 
+// Deprecated: ExportData does not identify a torrent or format and returns an
+// opaque string. Use ExportTorrent instead.
 func (s *GeneralService) ExportData() (string, error) {
 	req, err := s.newRequest(http.MethodGet, constants.PATH_TORRENTS_EXPORT_DATA, nil, nil)
 	if err != nil {
@@ -195,12 +288,77 @@ func (s *GeneralService) ExportData() (string, error) {
 	}
 
 	if !resp.Success {
-		return "", fmt.Errorf("failed to export data: %s", resp.Detail)
+		return "", responseError("export data", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
 }
 
+// ExportTorrent exports a torrent as a parsed magnet link or as .torrent file
+// bytes together with the file parsed by pkg/torrent.
+func (s *GeneralService) ExportTorrent(torrentId int64, format constants.ExportFormat) (*models.TorrentExport, error) {
+	params := &url.Values{}
+	params.Add("torrent_id", fmt.Sprintf("%d", torrentId))
+	params.Add("type", string(format))
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_TORRENTS_EXPORT_DATA, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case constants.ExportFormatMagnet:
+		var resp models.ExportDataResponse
+		err = s.do(req, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.Success {
+			return nil, responseError("export torrent", resp.BaseResponse)
+		}
+
+		exportedMagnet, err := magnet.NewMagnet(resp.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.TorrentExport{
+			Format: format,
+			Magnet: exportedMagnet,
+		}, nil
+	case constants.ExportFormatFile:
+		req.Header.Set("Accept", "application/x-bittorrent")
+
+		data, err := s.doRaw(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// errors are still reported in the JSON envelope
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			var resp models.BaseResponse
+			err = json.Unmarshal(data, &resp)
+			if err == nil && !resp.Success {
+				return nil, responseError("export torrent", resp)
+			}
+		}
+
+		parsed, err := torrent.Parse(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse exported torrent: %w", err)
+		}
+
+		return &models.TorrentExport{
+			Format:  format,
+			Data:    data,
+			Torrent: parsed,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
 func (s *GeneralService) SearchTorrents(query string) ([]models.Torrent, error) {
 	params := &url.Values{}
 	params.Add("query", query)
@@ -217,7 +375,7 @@ func (s *GeneralService) SearchTorrents(query string) ([]models.Torrent, error)
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to search torrents: %s", resp.Detail)
+		return nil, responseError("search torrents", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -240,7 +398,7 @@ func (s *GeneralService) StoreSearch(query string) error {
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to store search: %s", resp.Detail)
+		return responseError("store search", resp)
 	}
 
 	return nil
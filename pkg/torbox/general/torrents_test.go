@@ -0,0 +1,233 @@
+package general
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const (
+	testHash        = "0123456789abcdef0123456789abcdef01234567"
+	testMagnet      = "magnet:?xt=urn:btih:" + testHash + "&dn=ubuntu"
+	testTorrentFile = "d8:announce28:https://tracker.example.com/4:infod6:lengthi42e4:name6:ubuntu12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaaee"
+)
+
+func TestExportTorrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   constants.ExportFormat
+		body     string
+		expected error
+	}{
+		{
+			name:   "magnet",
+			format: constants.ExportFormatMagnet,
+			body:   `{"success":true,"data":"` + testMagnet + `"}`,
+		},
+		{
+			name:   "file",
+			format: constants.ExportFormatFile,
+			body:   testTorrentFile,
+		},
+		{
+			name:     "magnet error",
+			format:   constants.ExportFormatMagnet,
+			body:     `{"success":false,"error":"ITEM_NOT_FOUND","detail":"missing"}`,
+			expected: torboxerrors.ErrDownloadNotFound,
+		},
+		{
+			name:     "file error envelope",
+			format:   constants.ExportFormatFile,
+			body:     `{"success":false,"error":"ITEM_NOT_FOUND","detail":"missing"}`,
+			expected: torboxerrors.ErrDownloadNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/"+constants.PATH_TORRENTS_EXPORT_DATA {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				query := r.URL.Query()
+				if query.Get("torrent_id") != "7" || query.Get("type") != string(tt.format) {
+					t.Errorf("unexpected query %s", r.URL.RawQuery)
+				}
+
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			export, err := service.ExportTorrent(7, tt.format)
+			if tt.expected != nil {
+				var apiErr *torboxerrors.APIError
+				if !errors.Is(err, tt.expected) || !errors.As(err, &apiErr) {
+					t.Fatalf("error = %v, want an APIError matching %v", err, tt.expected)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if export.Format != tt.format {
+				t.Errorf("expected format %s, got %s", tt.format, export.Format)
+			}
+
+			switch tt.format {
+			case constants.ExportFormatMagnet:
+				if export.Magnet == nil || export.Magnet.Hash != testHash || export.Magnet.DisplayName != "ubuntu" {
+					t.Errorf("unexpected magnet %+v", export.Magnet)
+				}
+			case constants.ExportFormatFile:
+				if !bytes.Equal(export.Data, []byte(testTorrentFile)) {
+					t.Errorf("expected the raw .torrent bytes, got %q", export.Data)
+				}
+
+				if export.Torrent == nil || !slices.Equal(export.Torrent.Announce, []string{"https://tracker.example.com/"}) {
+					t.Fatalf("unexpected parsed torrent %+v", export.Torrent)
+				}
+
+				if len(export.Torrent.Files) != 1 || export.Torrent.Files[0].Length != 42 {
+					t.Errorf("unexpected files %+v", export.Torrent.Files)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewTorrent(t *testing.T) {
+	testMagnetLink, err := magnet.NewMagnet(testMagnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		request        models.TorrentInfoRequest
+		expectedMethod string
+		expected       map[string]string
+		expectedFile   string
+	}{
+		{
+			name:           "hash",
+			request:        models.TorrentInfoRequest{Hash: testHash, Timeout: 30 * time.Second},
+			expectedMethod: http.MethodGet,
+			expected:       map[string]string{"hash": testHash, "timeout": "30"},
+		},
+		{
+			name:           "magnet",
+			request:        models.TorrentInfoRequest{Magnet: testMagnetLink, Timeout: 30 * time.Second},
+			expectedMethod: http.MethodPost,
+			expected:       map[string]string{"magnet": testMagnet, "timeout": "30"},
+		},
+		{
+			name:           "file",
+			request:        models.TorrentInfoRequest{File: []byte(testTorrentFile), Timeout: 30 * time.Second},
+			expectedMethod: http.MethodPost,
+			expected:       map[string]string{"timeout": "30"},
+			expectedFile:   testTorrentFile,
+		},
+		{
+			name:           "default timeout is left out",
+			request:        models.TorrentInfoRequest{Hash: testHash},
+			expectedMethod: http.MethodGet,
+			expected:       map[string]string{"hash": testHash},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != tt.expectedMethod || r.URL.Path != "/"+constants.PATH_TORRENTS_INFO {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+
+				err := r.ParseMultipartForm(1 << 20)
+				if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+					t.Fatalf("failed to parse form: %v", err)
+				}
+
+				// hashes go in the query, magnets and files in the posted form
+				values := r.PostForm
+				if r.Method == http.MethodGet {
+					values = r.URL.Query()
+				}
+
+				fields := make(map[string]string)
+				for key := range values {
+					fields[key] = values.Get(key)
+				}
+
+				if len(fields) != len(tt.expected) {
+					t.Errorf("expected fields %v, got %v", tt.expected, fields)
+				}
+
+				for key, value := range tt.expected {
+					if fields[key] != value {
+						t.Errorf("expected %s=%q, got %q", key, value, fields[key])
+					}
+				}
+
+				if tt.expectedFile != "" {
+					file, _, err := r.FormFile("torrent_file")
+					if err != nil {
+						t.Fatalf("missing torrent_file part: %v", err)
+					}
+
+					data, _ := io.ReadAll(file)
+					if string(data) != tt.expectedFile {
+						t.Errorf("expected torrent_file %q, got %q", tt.expectedFile, data)
+					}
+				}
+
+				w.Write([]byte(`{"success":true,"data":{"hash":"` + testHash + `","name":"ubuntu","size":42,"files":[{"id":0,"name":"ubuntu.iso","size":40},{"id":1,"name":"README","size":2}]}}`))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			preview, err := service.PreviewTorrent(tt.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if preview.Name != "ubuntu" || preview.Size != 42 {
+				t.Errorf("unexpected preview %s (%d bytes)", preview.Name, preview.Size)
+			}
+
+			var names []string
+			for _, file := range preview.Files {
+				names = append(names, file.Name)
+			}
+
+			if !slices.Equal(names, []string{"ubuntu.iso", "README"}) {
+				t.Errorf("unexpected files %v", names)
+			}
+		})
+	}
+}
+
+func TestPreviewTorrentNoSource(t *testing.T) {
+	_, err := New(http.Client{}, "token").PreviewTorrent(models.TorrentInfoRequest{})
+	if !errors.Is(err, torboxerrors.ErrNoTorrentSource) {
+		t.Errorf("error = %v, want ErrNoTorrentSource", err)
+	}
+}
@@ -1,16 +1,56 @@
 package general
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 )
 
+// CreateUsenetDownload adds a usenet download from a link, or uploads the NZB
+// in File when it is set.
 func (s *GeneralService) CreateUsenetDownload(r models.CreateUsenetRequest) (*models.UsenetDownload, error) {
-	req, err := s.newRequest(http.MethodPost, constants.PATH_USENET_CREATE, &url.Values{"bodyType": {"json"}}, r)
+	var params = &url.Values{"bodyType": {"json"}}
+	var reqBody any = r
+
+	if r.File != nil {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+
+		fileName := r.FileName
+		if fileName == "" {
+			fileName = "download.nzb"
+		}
+
+		part, err := writer.CreateFormFile("file", fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = part.Write(r.File)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, values := range createUsenetOptions(r) {
+			writer.WriteField(key, values[0])
+		}
+
+		writer.Close()
+		params.Set("bodyType", "file")
+		params.Set("Content-Type", writer.FormDataContentType())
+
+		reqBody = body
+	}
+
+	req, err := s.newRequest(http.MethodPost, constants.PATH_USENET_CREATE, params, reqBody)
 	if err != nil {
 		return nil, err
 	}
@@ -22,12 +62,59 @@ func (s *GeneralService) CreateUsenetDownload(r models.CreateUsenetRequest) (*mo
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to create usenet download: %s", resp.Detail)
+		return nil, responseError("create usenet download", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
 }
 
+// CreateUsenetDownloadFromReader uploads the NZB read from reader, the file
+// name is shown in TorBox when r.Name is not set.
+func (s *GeneralService) CreateUsenetDownloadFromReader(reader io.Reader, fileName string, r models.CreateUsenetRequest) (*models.UsenetDownload, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	r.File = data
+	r.FileName = fileName
+
+	return s.CreateUsenetDownload(r)
+}
+
+func (s *GeneralService) CreateUsenetDownloadFromFile(path string, r models.CreateUsenetRequest) (*models.UsenetDownload, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return s.CreateUsenetDownloadFromReader(file, filepath.Base(path), r)
+}
+
+// createUsenetOptions encodes the optional settings sent with an NZB upload.
+func createUsenetOptions(r models.CreateUsenetRequest) url.Values {
+	form := url.Values{}
+
+	if r.Name != nil {
+		form.Set("name", *r.Name)
+	}
+
+	if r.Password != nil {
+		form.Set("password", *r.Password)
+	}
+
+	if r.PostProcessing != nil {
+		form.Set("post_processing", fmt.Sprintf("%d", *r.PostProcessing))
+	}
+
+	if r.AsQueued != nil {
+		form.Set("as_queued", fmt.Sprintf("%t", *r.AsQueued))
+	}
+
+	return form
+}
+
 func (s *GeneralService) GetUsenetList() ([]models.UsenetDownload, error) {
 	params := &url.Values{}
 	params.Add("bypass_cache", "true")
@@ -44,7 +131,7 @@ func (s *GeneralService) GetUsenetList() ([]models.UsenetDownload, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get usenet list: %s", resp.Detail)
+		return nil, responseError("get usenet list", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -68,7 +155,7 @@ func (s *GeneralService) ControlUsenetDownload(usenetId int64, operation constan
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to control usenet download: %s", resp.Detail)
+		return responseError("control usenet download", resp)
 	}
 
 	return nil
@@ -92,20 +179,14 @@ func (s *GeneralService) GetUsenetDownloadUrl(usenetId int64, fileId int64) (*st
 	}
 
 	if !resp.Success {
-		if resp.Error != "" && resp.Detail != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s - %s", resp.Error, resp.Detail)
-		} else if resp.Error != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s", resp.Error)
-		} else if resp.Detail != "" {
-			return nil, fmt.Errorf("failed to get download URL: %s", resp.Detail)
-		} else {
-			return nil, fmt.Errorf("failed to get download URL: unknown error")
-		}
+		return nil, responseError("get download URL", resp.BaseResponse)
 	}
 
 	return &resp.DownloadUrl, nil
 }
 
+// CheckUsenetCached returns the cache entry for hash, or nil when the usenet
+// download is not cached.
 func (s *GeneralService) CheckUsenetCached(hash string) (*models.CacheCheckResponse, error) {
 	params := &url.Values{}
 	params.Add("hash", hash)
@@ -116,15 +197,19 @@ func (s *GeneralService) CheckUsenetCached(hash string) (*models.CacheCheckRespo
 		return nil, err
 	}
 
-	var resp models.CheckCachedResponse
+	var resp models.CheckCachedListResponse
 	err = s.do(req, &resp)
 	if err != nil {
 		return nil, err
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to check usenet cache: %s", resp.Detail)
+		return nil, responseError("check usenet cache", resp.BaseResponse)
 	}
 
-	return resp.Data, nil
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+
+	return &resp.Data[0], nil
 }
@@ -0,0 +1,123 @@
+package general
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const testNZB = `<?xml version="1.0" encoding="UTF-8"?><nzb xmlns="http://www.newzbin.com/DTD/2003/nzb"></nzb>`
+
+func TestCreateUsenetDownloadUpload(t *testing.T) {
+	name := "fedora"
+	password := "secret"
+	postProcessing := constants.PostProcessingRepairUnpack
+	asQueued := true
+
+	options := models.CreateUsenetRequest{
+		Name:           &name,
+		Password:       &password,
+		PostProcessing: &postProcessing,
+		AsQueued:       &asQueued,
+	}
+
+	nzbPath := filepath.Join(t.TempDir(), "fedora.nzb")
+	err := os.WriteFile(nzbPath, []byte(testNZB), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		create           func(s *GeneralService) (*models.UsenetDownload, error)
+		expectedFileName string
+	}{
+		{
+			name: "bytes",
+			create: func(s *GeneralService) (*models.UsenetDownload, error) {
+				r := options
+				r.File = []byte(testNZB)
+				return s.CreateUsenetDownload(r)
+			},
+			expectedFileName: "download.nzb",
+		},
+		{
+			name: "reader",
+			create: func(s *GeneralService) (*models.UsenetDownload, error) {
+				return s.CreateUsenetDownloadFromReader(strings.NewReader(testNZB), "upload.nzb", options)
+			},
+			expectedFileName: "upload.nzb",
+		},
+		{
+			name: "path",
+			create: func(s *GeneralService) (*models.UsenetDownload, error) {
+				return s.CreateUsenetDownloadFromFile(nzbPath, options)
+			},
+			expectedFileName: "fedora.nzb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/"+constants.PATH_USENET_CREATE {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+
+				err := r.ParseMultipartForm(1 << 20)
+				if err != nil {
+					t.Fatalf("expected a multipart body: %v", err)
+				}
+
+				file, header, err := r.FormFile("file")
+				if err != nil {
+					t.Fatalf("missing file part: %v", err)
+				}
+
+				data, _ := io.ReadAll(file)
+				if string(data) != testNZB {
+					t.Errorf("expected the NZB contents, got %q", data)
+				}
+
+				if header.Filename != tt.expectedFileName {
+					t.Errorf("expected file name %s, got %s", tt.expectedFileName, header.Filename)
+				}
+
+				expected := map[string]string{
+					"name":            "fedora",
+					"password":        "secret",
+					"post_processing": "2",
+					"as_queued":       "true",
+				}
+
+				for key, value := range expected {
+					if r.PostForm.Get(key) != value {
+						t.Errorf("expected %s=%q, got %q", key, value, r.PostForm.Get(key))
+					}
+				}
+
+				w.Write([]byte(`{"success":true,"data":{"id":12,"hash":"abc","name":"fedora"}}`))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			download, err := tt.create(service)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if download == nil || download.ID != 12 || download.Name != "fedora" {
+				t.Errorf("unexpected usenet download %+v", download)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package general
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -22,7 +21,7 @@ func (s *GeneralService) GetUser() (*models.User, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to get user: %s", resp.Detail)
+		return nil, responseError("get user", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -41,7 +40,7 @@ func (s *GeneralService) RefreshToken() (*string, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to refresh token: %s", resp.Detail)
+		return nil, responseError("refresh token", resp.BaseResponse)
 	}
 
 	return &resp.Data.Token, nil
@@ -64,8 +63,91 @@ func (s *GeneralService) AddReferral(referralCode string) error {
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to add referral: %s", resp.Detail)
+		return responseError("add referral", resp)
 	}
 
 	return nil
 }
+
+func (s *GeneralService) GetUserSettings() (*models.UserSettings, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_USER_SETTINGS, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetUserSettingsResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get user settings", resp.BaseResponse)
+	}
+
+	return &resp.Data, nil
+}
+
+// UpdateUserSettings validates and applies the settings set in r, then reads
+// the settings back.
+func (s *GeneralService) UpdateUserSettings(r models.UpdateUserSettingsRequest) (*models.UserSettings, error) {
+	err := r.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.newRequest(http.MethodPut, constants.PATH_USER_EDIT_SETTINGS, &url.Values{"bodyType": {"json"}}, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.BaseResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("update user settings", resp)
+	}
+
+	return s.GetUserSettings()
+}
+
+func (s *GeneralService) GetSubscriptions() ([]models.Subscription, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_USER_SUBSCRIPTIONS, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetSubscriptionsResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get subscriptions", resp.BaseResponse)
+	}
+
+	return resp.Data, nil
+}
+
+func (s *GeneralService) GetTransactions() ([]models.Transaction, error) {
+	req, err := s.newRequest(http.MethodGet, constants.PATH_USER_TRANSACTIONS, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetTransactionsResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get transactions", resp.BaseResponse)
+	}
+
+	return resp.Data, nil
+}
@@ -0,0 +1,165 @@
+package general
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const userSettingsBody = `{"success":true,"data":{"seed_torrents":3,"allow_zipped":true,"preferred_location":"eu","webhook_url":"https://hooks.example.com/torbox"}}`
+
+func TestGetUserSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/"+constants.PATH_USER_SETTINGS {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Write([]byte(userSettingsBody))
+	}))
+	defer server.Close()
+
+	service := New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	settings, err := service.GetUserSettings()
+	if err != nil {
+		t.Fatalf("GetUserSettings() error = %v", err)
+	}
+
+	expected := models.UserSettings{
+		SeedTorrents:      constants.NoSeed,
+		AllowZipped:       true,
+		PreferredLocation: "eu",
+		WebhookURL:        "https://hooks.example.com/torbox",
+	}
+
+	if *settings != expected {
+		t.Errorf("GetUserSettings() = %+v, want %+v", *settings, expected)
+	}
+}
+
+func TestUpdateUserSettings(t *testing.T) {
+	seed := constants.NoSeed
+	webhook := ""
+
+	tests := []struct {
+		name          string
+		request       models.UpdateUserSettingsRequest
+		expectedBody  map[string]any
+		expectedCalls []string
+		wantErr       error
+	}{
+		{
+			name:          "only set fields are sent",
+			request:       models.UpdateUserSettingsRequest{SeedTorrents: &seed, WebhookURL: &webhook},
+			expectedBody:  map[string]any{"seed_torrents": float64(3), "webhook_url": ""},
+			expectedCalls: []string{http.MethodPut + " /" + constants.PATH_USER_EDIT_SETTINGS, http.MethodGet + " /" + constants.PATH_USER_SETTINGS},
+		},
+		{
+			name:    "invalid settings are not sent",
+			request: models.UpdateUserSettingsRequest{WebhookURL: func() *string { s := "hooks.example.com"; return &s }()},
+			wantErr: torboxerrors.ErrInvalidSettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			var body map[string]any
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				switch r.URL.Path {
+				case "/" + constants.PATH_USER_EDIT_SETTINGS:
+					if r.Header.Get("Content-Type") != "application/json" {
+						t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+					}
+
+					data, _ := io.ReadAll(r.Body)
+					json.Unmarshal(data, &body)
+
+					w.Write([]byte(`{"success":true}`))
+				case "/" + constants.PATH_USER_SETTINGS:
+					w.Write([]byte(userSettingsBody))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			settings, err := service.UpdateUserSettings(tt.request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateUserSettings() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(calls) != len(tt.expectedCalls) {
+				t.Fatalf("expected calls %v, got %v", tt.expectedCalls, calls)
+			}
+
+			for i := range calls {
+				if calls[i] != tt.expectedCalls[i] {
+					t.Errorf("call %d = %s, want %s", i, calls[i], tt.expectedCalls[i])
+				}
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !maps.Equal(body, tt.expectedBody) {
+				t.Errorf("sent %v, want %v", body, tt.expectedBody)
+			}
+
+			if settings == nil || settings.SeedTorrents != constants.NoSeed || settings.PreferredLocation != "eu" {
+				t.Errorf("unexpected settings read back %+v", settings)
+			}
+		})
+	}
+}
+
+func TestGetSubscriptionsAndTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + constants.PATH_USER_SUBSCRIPTIONS:
+			w.Write([]byte(`{"success":true,"data":[{"id":1,"plan":"pro","status":"active","amount":10,"currency":"USD","interval":"month"}]}`))
+		case "/" + constants.PATH_USER_TRANSACTIONS:
+			w.Write([]byte(`{"success":true,"data":[{"id":2,"type":"payment","plan":2,"amount":10,"currency":"USD"},{"id":3,"type":"payment","plan":"platinum"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	service := New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	subscriptions, err := service.GetSubscriptions()
+	if err != nil {
+		t.Fatalf("GetSubscriptions() error = %v", err)
+	}
+
+	if len(subscriptions) != 1 || subscriptions[0].Plan != constants.PlanPro || subscriptions[0].Status != "active" {
+		t.Errorf("unexpected subscriptions %+v", subscriptions)
+	}
+
+	transactions, err := service.GetTransactions()
+	if err != nil {
+		t.Fatalf("GetTransactions() error = %v", err)
+	}
+
+	if len(transactions) != 2 || transactions[0].Plan != constants.PlanPro || transactions[1].Plan != constants.PlanUnknown {
+		t.Errorf("unexpected transactions %+v", transactions)
+	}
+}
@@ -4,12 +4,31 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
 )
 
+const (
+	webDownloadPageSize = 1000
+	webDownloadMaxPages = 100
+	hosterCacheTTL      = time.Hour
+)
+
+// CreateWebDownload adds a web download. With r.CheckHoster set, the link is
+// checked against the supported hosters first, so unsupported links fail
+// without using a download slot.
 func (s *GeneralService) CreateWebDownload(r models.CreateWebDownloadRequest) (*models.WebDownload, error) {
+	if r.CheckHoster {
+		_, err := s.ValidateWebLink(r.Link)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := s.newRequest(http.MethodPost, constants.PATH_WEBDL_CREATE, &url.Values{"bodyType": {"json"}}, r)
 	if err != nil {
 		return nil, err
@@ -22,7 +41,72 @@ func (s *GeneralService) CreateWebDownload(r models.CreateWebDownloadRequest) (*
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("failed to create web download: %s", resp.Detail)
+		return nil, responseError("create web download", resp.BaseResponse)
+	}
+
+	return resp.Data, nil
+}
+
+// GetWebDownloadList returns every web download, following pages until the
+// API returns a short one. Paging also stops once a page adds no new IDs, in
+// case the API ignores the offset, and after webDownloadMaxPages pages.
+func (s *GeneralService) GetWebDownloadList() ([]models.WebDownload, error) {
+	var webDownloads []models.WebDownload
+	seen := make(map[int64]bool)
+
+	for pageNum := 0; pageNum < webDownloadMaxPages; pageNum++ {
+		page, err := s.GetWebDownloadPage(models.ListOptions{
+			Offset: pageNum * webDownloadPageSize,
+			Limit:  webDownloadPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, webDownload := range page {
+			if seen[webDownload.ID] {
+				continue
+			}
+
+			seen[webDownload.ID] = true
+			webDownloads = append(webDownloads, webDownload)
+			added++
+		}
+
+		if len(page) < webDownloadPageSize || added == 0 {
+			return webDownloads, nil
+		}
+	}
+
+	return webDownloads, nil
+}
+
+func (s *GeneralService) GetWebDownloadPage(opts models.ListOptions) ([]models.WebDownload, error) {
+	params := &url.Values{}
+	params.Add("bypass_cache", "true")
+
+	if opts.Offset > 0 {
+		params.Add("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+
+	if opts.Limit > 0 {
+		params.Add("limit", fmt.Sprintf("%d", opts.Limit))
+	}
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_WEBDL_GET_LIST, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetWebDownloadListResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get web download list", resp.BaseResponse)
 	}
 
 	return resp.Data, nil
@@ -46,8 +130,136 @@ func (s *GeneralService) ControlWebDownload(webId int64, operation constants.Con
 	}
 
 	if !resp.Success {
-		return fmt.Errorf("failed to control web download: %s", resp.Detail)
+		return responseError("control web download", resp)
 	}
 
 	return nil
 }
+
+func (s *GeneralService) GetWebDownloadUrl(webId int64, fileId int64) (*string, error) {
+	params := &url.Values{}
+	params.Add("web_id", fmt.Sprintf("%d", webId))
+	params.Add("file_id", fmt.Sprintf("%d", fileId))
+	params.Add("token", "")
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_WEBDL_GET_DOWNLOAD, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetDownloadUrlResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get download URL", resp.BaseResponse)
+	}
+
+	return &resp.DownloadUrl, nil
+}
+
+// CheckWebDownloadCached returns the cache entry for hash, or nil when the
+// web download is not cached.
+func (s *GeneralService) CheckWebDownloadCached(hash string) (*models.CacheCheckResponse, error) {
+	params := &url.Values{}
+	params.Add("hash", hash)
+	params.Add("format", "list")
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_WEBDL_CHECK_CACHED, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.CheckCachedListResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("check web download cache", resp.BaseResponse)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+
+	return &resp.Data[0], nil
+}
+
+// GetHosters lists the hosters TorBox can download from. The list is cached
+// for an hour since it rarely changes.
+func (s *GeneralService) GetHosters() ([]models.Hoster, error) {
+	s.hostersMu.Lock()
+	defer s.hostersMu.Unlock()
+
+	if s.hosters != nil && time.Since(s.hostersFetched) < hosterCacheTTL {
+		return s.hosters, nil
+	}
+
+	req, err := s.newRequest(http.MethodGet, constants.PATH_WEBDL_HOSTERS, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.GetHostersResponse
+	err = s.do(req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Success {
+		return nil, responseError("get hosters", resp.BaseResponse)
+	}
+
+	s.hosters = resp.Data
+	s.hostersFetched = time.Now()
+
+	return s.hosters, nil
+}
+
+// ValidateWebLink returns the hoster serving link, or ErrUnsupportedHoster
+// when no supported and online hoster matches its domain.
+func (s *GeneralService) ValidateWebLink(link string) (*models.Hoster, error) {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsedLink.Scheme != "http" && parsedLink.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q is not an http link", torboxerrors.ErrUnsupportedHoster, link)
+	}
+
+	hosters, err := s.GetHosters()
+	if err != nil {
+		return nil, err
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsedLink.Hostname()), "www.")
+	for _, hoster := range hosters {
+		if !hosterMatches(hoster, host) {
+			continue
+		}
+
+		if !hoster.Status {
+			return nil, fmt.Errorf("%w: %s is currently unavailable", torboxerrors.ErrUnsupportedHoster, hoster.Name)
+		}
+
+		return &hoster, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", torboxerrors.ErrUnsupportedHoster, host)
+}
+
+func hosterMatches(hoster models.Hoster, host string) bool {
+	for _, domain := range hoster.Domains {
+		domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+
+	return false
+}
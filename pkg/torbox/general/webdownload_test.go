@@ -0,0 +1,201 @@
+package general
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestGetWebDownloadList(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		ignoresOffset bool
+		expected      int
+		expectedPages int
+	}{
+		{
+			name:          "single short page",
+			total:         3,
+			expected:      3,
+			expectedPages: 1,
+		},
+		{
+			name:          "full page followed by short page",
+			total:         webDownloadPageSize + 2,
+			expected:      webDownloadPageSize + 2,
+			expectedPages: 2,
+		},
+		{
+			name:          "offset ignored stops when nothing new",
+			total:         webDownloadPageSize,
+			ignoresOffset: true,
+			expected:      webDownloadPageSize,
+			expectedPages: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages++
+
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				if tt.ignoresOffset {
+					offset = 0
+				}
+
+				var data []models.WebDownload
+				for id := offset; id < tt.total && id < offset+webDownloadPageSize; id++ {
+					data = append(data, models.WebDownload{ID: int64(id + 1)})
+				}
+
+				json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			webDownloads, err := service.GetWebDownloadList()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(webDownloads) != tt.expected {
+				t.Errorf("expected %d web downloads, got %d", tt.expected, len(webDownloads))
+			}
+
+			if pages != tt.expectedPages {
+				t.Errorf("expected %d pages, got %d", tt.expectedPages, pages)
+			}
+		})
+	}
+}
+
+func TestCheckWebDownloadCached(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected *models.CacheCheckResponse
+	}{
+		{
+			name:     "cached",
+			body:     `{"success":true,"data":[{"name":"file.bin","size":42,"hash":"abc"}]}`,
+			expected: &models.CacheCheckResponse{Name: "file.bin", Size: 42, Hash: "abc"},
+		},
+		{
+			name:     "not cached",
+			body:     `{"success":true,"data":[]}`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/"+constants.PATH_WEBDL_CHECK_CACHED {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			cached, err := service.CheckWebDownloadCached("abc")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if (cached == nil) != (tt.expected == nil) {
+				t.Fatalf("expected %v, got %v", tt.expected, cached)
+			}
+
+			if cached != nil && (cached.Size != tt.expected.Size || cached.Name != tt.expected.Name || cached.Hash != tt.expected.Hash) {
+				t.Errorf("expected %+v, got %+v", *tt.expected, *cached)
+			}
+		})
+	}
+}
+
+func TestCreateWebDownload(t *testing.T) {
+	tests := []struct {
+		name          string
+		link          string
+		checkHoster   bool
+		expectedErr   error
+		expectedCalls []string
+	}{
+		{
+			name:          "direct link is submitted without a hoster check",
+			link:          "https://files.example.org/ubuntu.iso",
+			expectedCalls: []string{constants.PATH_WEBDL_CREATE},
+		},
+		{
+			name:          "supported hoster passes the check",
+			link:          "https://www.example-hoster.com/file/abc",
+			checkHoster:   true,
+			expectedCalls: []string{constants.PATH_WEBDL_HOSTERS, constants.PATH_WEBDL_CREATE},
+		},
+		{
+			name:          "unknown host fails the check",
+			link:          "https://files.example.org/ubuntu.iso",
+			checkHoster:   true,
+			expectedErr:   torboxerrors.ErrUnsupportedHoster,
+			expectedCalls: []string{constants.PATH_WEBDL_HOSTERS},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, strings.TrimPrefix(r.URL.Path, "/"))
+
+				switch r.URL.Path {
+				case "/" + constants.PATH_WEBDL_HOSTERS:
+					w.Write([]byte(`{"success":true,"data":[{"name":"Example","domains":["example-hoster.com"],"status":true}]}`))
+				case "/" + constants.PATH_WEBDL_CREATE:
+					w.Write([]byte(`{"success":true,"data":{"id":5,"name":"ubuntu.iso"}}`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			service := New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			download, err := service.CreateWebDownload(models.CreateWebDownloadRequest{
+				Link:        tt.link,
+				CheckHoster: tt.checkHoster,
+			})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("error = %v, want %v", err, tt.expectedErr)
+			}
+
+			if tt.expectedErr == nil && (download == nil || download.ID != 5) {
+				t.Errorf("unexpected web download %+v", download)
+			}
+
+			if !slices.Equal(calls, tt.expectedCalls) {
+				t.Errorf("expected calls %v, got %v", tt.expectedCalls, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package janitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Janitor escalates torrents that stay stuck: reannounce, then pause, then
+// delete. TorBox does not report when a torrent entered its current state, so
+// the janitor tracks that itself and can persist it between runs.
+type Janitor struct {
+	general *general.GeneralService
+	policy  Policy
+
+	dryRun    bool
+	statePath string
+
+	tracked map[int64]*tracked
+	loaded  bool
+	now     func() time.Time
+}
+
+type tracked struct {
+	State    constants.DownloadState `json:"state"`
+	Since    time.Time               `json:"since"`
+	LastStep Step                    `json:"last_step"`
+}
+
+type Option func(*Janitor)
+
+// WithDryRun reports the actions that would be taken without performing them.
+func WithDryRun(dryRun bool) Option {
+	return func(j *Janitor) {
+		j.dryRun = dryRun
+	}
+}
+
+// WithStateFile persists how long each torrent has been stuck so escalation
+// survives restarts and one-shot invocations.
+func WithStateFile(path string) Option {
+	return func(j *Janitor) {
+		j.statePath = path
+	}
+}
+
+func New(generalService *general.GeneralService, policy Policy, opts ...Option) *Janitor {
+	j := &Janitor{
+		general: generalService,
+		policy:  policy,
+
+		tracked: make(map[int64]*tracked),
+		now:     time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	return j
+}
+
+// Run examines every active torrent once and applies the policy.
+func (j *Janitor) Run() (*Report, error) {
+	err := j.load()
+	if err != nil {
+		return nil, err
+	}
+
+	activeTorrents, err := j.general.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	now := j.now()
+	report := &Report{
+		DryRun:    j.dryRun,
+		StartedAt: now,
+		Examined:  len(activeTorrents),
+	}
+
+	present := make(map[int64]bool, len(activeTorrents))
+	for _, t := range activeTorrents {
+		present[t.ID] = true
+
+		action, ok := j.examine(t, now)
+		if !ok {
+			continue
+		}
+
+		report.Actions = append(report.Actions, action)
+	}
+
+	for id := range j.tracked {
+		if !present[id] {
+			delete(j.tracked, id)
+		}
+	}
+
+	report.FinishedAt = j.now()
+
+	err = j.save()
+	if err != nil {
+		return report, err
+	}
+
+	return report, report.Err()
+}
+
+func (j *Janitor) examine(t models.Torrent, now time.Time) (Action, bool) {
+	entry, isTracked := j.tracked[t.ID]
+
+	// a torrent the janitor paused keeps escalating rather than starting over
+	pausedByJanitor := isTracked && entry.LastStep == StepPause && t.DownloadState.IsPaused()
+	if !j.policy.isStuck(t.DownloadState) && !pausedByJanitor {
+		delete(j.tracked, t.ID)
+		return Action{}, false
+	}
+
+	if !isTracked || (entry.State != t.DownloadState && !pausedByJanitor) {
+		// UpdatedAt says nothing about how long the state has lasted, so the
+		// clock starts when the janitor first sees it
+		entry = &tracked{
+			State: t.DownloadState,
+			Since: now,
+		}
+
+		j.tracked[t.ID] = entry
+	}
+
+	action := Action{
+		TorrentID:        t.ID,
+		Name:             t.Name,
+		State:            t.DownloadState,
+		StuckFor:         now.Sub(entry.Since),
+		Seeds:            t.Seeds,
+		Peers:            t.Peers,
+		Availability:     t.Availability,
+		LastKnownSeeders: t.LastKnownSeeders,
+		DryRun:           j.dryRun,
+	}
+
+	if j.policy.hasSources(t) {
+		action.Reason = "has seeds or availability"
+		return action, true
+	}
+
+	step := j.policy.nextStep(entry.LastStep, action.StuckFor)
+	if step == StepNone {
+		action.Reason = fmt.Sprintf("waiting, last step %q", entry.LastStep)
+		if entry.LastStep == StepNone {
+			action.Reason = "below reannounce threshold"
+		}
+
+		return action, true
+	}
+
+	action.Step = step
+	action.Reason = fmt.Sprintf("stuck for %s", action.StuckFor.Round(time.Minute))
+
+	if j.dryRun {
+		return action, true
+	}
+
+	log.Info().
+		Int64("torrent_id", t.ID).
+		Str("state", string(t.DownloadState)).
+		Str("step", string(step)).
+		Dur("stuck_for", action.StuckFor).
+		Msg("janitor escalating stuck torrent")
+
+	action.Err = j.apply(t.ID, step)
+	if action.Err == nil {
+		entry.LastStep = step
+	}
+
+	return action, true
+}
+
+func (j *Janitor) apply(torrentId int64, step Step) error {
+	switch step {
+	case StepReannounce:
+		return j.general.ControlActiveTorrent(torrentId, constants.ControlActiveOperationReannounce)
+	case StepPause:
+		return j.general.ControlActiveTorrent(torrentId, constants.ControlActiveOperationPause)
+	case StepDelete:
+		return j.general.ControlActiveTorrent(torrentId, constants.ControlActiveOperationDelete)
+	default:
+		return nil
+	}
+}
+
+func (j *Janitor) load() error {
+	if j.loaded || j.statePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(j.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		j.loaded = true
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, &j.tracked)
+	if err != nil {
+		return fmt.Errorf("failed to read janitor state: %w", err)
+	}
+
+	j.loaded = true
+
+	return nil
+}
+
+func (j *Janitor) save() error {
+	if j.statePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(j.tracked, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(j.statePath, data, 0o644)
+}
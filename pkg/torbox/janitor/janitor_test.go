@@ -0,0 +1,141 @@
+package janitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+// fakeTorBox serves a single stuck torrent last updated long ago and records
+// every control operation sent for it. Pausing it moves it to paused.
+type fakeTorBox struct {
+	mu    sync.Mutex
+	state constants.DownloadState
+	ops   []constants.ControlActiveOperation
+}
+
+func (f *fakeTorBox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/"+constants.PATH_TORRENTS_CONTROL_ACTIVE {
+		var body models.ControlActiveTorrentRequest
+		json.NewDecoder(r.Body).Decode(&body)
+
+		f.ops = append(f.ops, body.Operation)
+		if body.Operation == constants.ControlActiveOperationPause {
+			f.state = constants.TorrentStatePaused
+		}
+
+		w.Write([]byte(`{"success":true}`))
+		return
+	}
+
+	fmt.Fprintf(w, `{"success":true,"data":[{"id":1,"name":"stuck","download_state":%q,"updated_at":"2020-01-01T00:00:00Z"}]}`, f.state)
+}
+
+func TestJanitorEscalation(t *testing.T) {
+	policy := DefaultPolicy()
+
+	noReannounce := DefaultPolicy()
+	noReannounce.ReannounceAfter = 0
+
+	tests := []struct {
+		name   string
+		policy Policy
+		// runs are offsets from the first run, expected holds the operation
+		// each run should send, empty for none
+		runs     []time.Duration
+		expected []constants.ControlActiveOperation
+	}{
+		{
+			name:     "old torrent is not deleted on first sight",
+			policy:   policy,
+			runs:     []time.Duration{0},
+			expected: []constants.ControlActiveOperation{""},
+		},
+		{
+			name:   "one step per run",
+			policy: policy,
+			runs:   []time.Duration{0, 7 * time.Hour, 100 * time.Hour, 101 * time.Hour, 102 * time.Hour},
+			expected: []constants.ControlActiveOperation{
+				"",
+				constants.ControlActiveOperationReannounce,
+				constants.ControlActiveOperationPause,
+				constants.ControlActiveOperationDelete,
+				"",
+			},
+		},
+		{
+			name:   "waits for each threshold",
+			policy: policy,
+			runs:   []time.Duration{0, 7 * time.Hour, 8 * time.Hour, 25 * time.Hour, 30 * time.Hour, 73 * time.Hour},
+			expected: []constants.ControlActiveOperation{
+				"",
+				constants.ControlActiveOperationReannounce,
+				"",
+				constants.ControlActiveOperationPause,
+				"",
+				constants.ControlActiveOperationDelete,
+			},
+		},
+		{
+			name:   "disabled step is skipped",
+			policy: noReannounce,
+			runs:   []time.Duration{0, 25 * time.Hour, 73 * time.Hour},
+			expected: []constants.ControlActiveOperation{
+				"",
+				constants.ControlActiveOperationPause,
+				constants.ControlActiveOperationDelete,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTorBox{state: constants.TorrentStateStalledNoSeeds}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			generalService := general.New(http.Client{}, "token")
+			generalService.BaseURL = server.URL
+
+			start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+			var now time.Time
+
+			j := New(generalService, tt.policy)
+			j.now = func() time.Time { return now }
+
+			for i, offset := range tt.runs {
+				now = start.Add(offset)
+				sent := len(fake.ops)
+
+				_, err := j.Run()
+				if err != nil {
+					t.Fatalf("run %d: Run() error = %v", i, err)
+				}
+
+				var got constants.ControlActiveOperation
+				switch len(fake.ops) - sent {
+				case 0:
+				case 1:
+					got = fake.ops[sent]
+				default:
+					t.Fatalf("run %d sent %v, expected at most one operation", i, fake.ops[sent:])
+				}
+
+				if got != tt.expected[i] {
+					t.Errorf("run %d at +%s sent %q, want %q (all: %v)", i, offset, got, tt.expected[i], fake.ops)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package janitor
+
+import (
+	"slices"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+type Step string
+
+const (
+	StepNone       Step = ""
+	StepReannounce Step = "reannounce"
+	StepPause      Step = "pause"
+	StepDelete     Step = "delete"
+)
+
+// rank orders steps so a torrent only ever escalates.
+func (s Step) rank() int {
+	switch s {
+	case StepReannounce:
+		return 1
+	case StepPause:
+		return 2
+	case StepDelete:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type Policy struct {
+	// States that count as stuck.
+	States []constants.DownloadState
+
+	// How long a torrent must have been stuck before each step is taken. A zero
+	// duration disables the step.
+	ReannounceAfter time.Duration
+	PauseAfter      time.Duration
+	DeleteAfter     time.Duration
+
+	// Torrents with at least this many seeds, or this much availability, are
+	// left alone even if their state looks stuck. Zero disables the check.
+	MinSeeds        int64
+	MinAvailability float64
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		States: []constants.DownloadState{
+			constants.TorrentStateStalledNoSeeds,
+			constants.TorrentStateStalledDL,
+			constants.TorrentStateMetaDL,
+		},
+		ReannounceAfter: 6 * time.Hour,
+		PauseAfter:      24 * time.Hour,
+		DeleteAfter:     72 * time.Hour,
+		MinSeeds:        1,
+	}
+}
+
+func (p Policy) isStuck(state constants.DownloadState) bool {
+	return slices.Contains(p.States, state)
+}
+
+// hasSources reports whether the tracker details show enough seeds to expect
+// the torrent to recover by itself.
+func (p Policy) hasSources(t models.Torrent) bool {
+	if p.MinSeeds > 0 && max(t.Seeds, t.LastKnownSeeders) >= p.MinSeeds {
+		return true
+	}
+
+	if p.MinAvailability > 0 && t.Availability >= p.MinAvailability {
+		return true
+	}
+
+	return false
+}
+
+// threshold returns how long a torrent must be stuck before step, zero when
+// the step is disabled.
+func (p Policy) threshold(step Step) time.Duration {
+	switch step {
+	case StepReannounce:
+		return p.ReannounceAfter
+	case StepPause:
+		return p.PauseAfter
+	case StepDelete:
+		return p.DeleteAfter
+	default:
+		return 0
+	}
+}
+
+// nextStep returns the first enabled step after last, if the torrent has been
+// stuck for long enough to take it. Escalation moves at most one step per run,
+// so a torrent is always reannounced and paused before it is deleted.
+func (p Policy) nextStep(last Step, d time.Duration) Step {
+	for _, step := range []Step{StepReannounce, StepPause, StepDelete} {
+		if step.rank() <= last.rank() {
+			continue
+		}
+
+		threshold := p.threshold(step)
+		if threshold == 0 {
+			continue
+		}
+
+		if d >= threshold {
+			return step
+		}
+
+		return StepNone
+	}
+
+	return StepNone
+}
@@ -0,0 +1,95 @@
+package janitor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// Action is the audit record for one stuck torrent examined during a run.
+type Action struct {
+	TorrentID int64
+	Name      string
+	State     constants.DownloadState
+	StuckFor  time.Duration
+
+	Seeds            int64
+	Peers            int64
+	Availability     float64
+	LastKnownSeeders int64
+
+	// Step is empty when the torrent was examined but left alone.
+	Step   Step
+	Reason string
+	DryRun bool
+	Err    error
+}
+
+type Report struct {
+	DryRun     bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Examined   int
+	Actions    []Action
+}
+
+// Taken returns the actions where a step was applied, or would have been in a dry run.
+func (r *Report) Taken() []Action {
+	var taken []Action
+	for _, action := range r.Actions {
+		if action.Step != StepNone {
+			taken = append(taken, action)
+		}
+	}
+
+	return taken
+}
+
+func (r *Report) Err() error {
+	var errs []error
+	for _, action := range r.Actions {
+		if action.Err != nil {
+			errs = append(errs, fmt.Errorf("%s torrent %d: %w", action.Step, action.TorrentID, action.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Report) Print(w io.Writer) {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"ID", "Name", "State", "Stuck For", "Seeds", "Peers", "Availability", "Step", "Reason", "Error"})
+
+	for _, action := range r.Actions {
+		step := string(action.Step)
+		if action.DryRun && action.Step != StepNone {
+			step += " (dry run)"
+		}
+
+		errStr := ""
+		if action.Err != nil {
+			errStr = action.Err.Error()
+		}
+
+		t.AppendRow(table.Row{
+			action.TorrentID,
+			action.Name,
+			action.State,
+			action.StuckFor.Round(time.Minute),
+			fmt.Sprintf("%d (last %d)", action.Seeds, action.LastKnownSeeders),
+			action.Peers,
+			action.Availability,
+			step,
+			action.Reason,
+			errStr,
+		})
+	}
+
+	t.AppendFooter(table.Row{"", fmt.Sprintf("%d examined, %d stuck, %d steps", r.Examined, len(r.Actions), len(r.Taken()))})
+	t.Render()
+}
@@ -0,0 +1,153 @@
+package library
+
+import (
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+type Kind string
+
+const (
+	KindTorrent     Kind = "torrent"
+	KindQueued      Kind = "queued"
+	KindUsenet      Kind = "usenet"
+	KindWebDownload Kind = "webdl"
+)
+
+var Kinds = []Kind{KindTorrent, KindQueued, KindUsenet, KindWebDownload}
+
+// Download is the common view over torrents, queued items, usenet and web
+// downloads. Control methods route to the API matching the item's kind.
+type Download interface {
+	Kind() Kind
+	ID() int64
+	Hash() string
+	Name() string
+	Size() int64
+	Progress() float64
+	State() constants.DownloadState
+	Files() []models.File
+
+	Pause() error
+	Resume() error
+	Delete() error
+}
+
+type torrentDownload struct {
+	torrent models.Torrent
+	general *general.GeneralService
+}
+
+func (d *torrentDownload) Kind() Kind                     { return KindTorrent }
+func (d *torrentDownload) ID() int64                      { return d.torrent.ID }
+func (d *torrentDownload) Hash() string                   { return d.torrent.Hash }
+func (d *torrentDownload) Name() string                   { return d.torrent.Name }
+func (d *torrentDownload) Size() int64                    { return d.torrent.Size }
+func (d *torrentDownload) Progress() float64              { return d.torrent.Progress }
+func (d *torrentDownload) State() constants.DownloadState { return d.torrent.DownloadState }
+func (d *torrentDownload) Files() []models.File           { return d.torrent.Files }
+
+// Torrent returns the underlying model.
+func (d *torrentDownload) Torrent() models.Torrent { return d.torrent }
+
+func (d *torrentDownload) Pause() error {
+	return d.general.ControlActiveTorrent(d.torrent.ID, constants.ControlActiveOperationPause)
+}
+
+func (d *torrentDownload) Resume() error {
+	return d.general.ControlActiveTorrent(d.torrent.ID, constants.ControlActiveOperationResume)
+}
+
+func (d *torrentDownload) Delete() error {
+	return d.general.ControlActiveTorrent(d.torrent.ID, constants.ControlActiveOperationDelete)
+}
+
+type queuedDownload struct {
+	queued  models.QueuedDownload
+	general *general.GeneralService
+}
+
+func (d *queuedDownload) Kind() Kind                     { return KindQueued }
+func (d *queuedDownload) ID() int64                      { return d.queued.ID }
+func (d *queuedDownload) Hash() string                   { return d.queued.Hash }
+func (d *queuedDownload) Name() string                   { return d.queued.Name }
+func (d *queuedDownload) Size() int64                    { return 0 }
+func (d *queuedDownload) Progress() float64              { return 0 }
+func (d *queuedDownload) State() constants.DownloadState { return constants.TorrentStateQueued }
+func (d *queuedDownload) Files() []models.File           { return nil }
+
+// Queued returns the underlying model.
+func (d *queuedDownload) Queued() models.QueuedDownload { return d.queued }
+
+func (d *queuedDownload) Pause() error {
+	return torboxerrors.ErrUnsupportedOperation
+}
+
+// Resume starts the queued item.
+func (d *queuedDownload) Resume() error {
+	return d.general.ControlQueuedTorrent(d.queued.ID, constants.ControlQueuedOperationStart)
+}
+
+func (d *queuedDownload) Delete() error {
+	return d.general.ControlQueuedTorrent(d.queued.ID, constants.ControlQueuedOperationDelete)
+}
+
+type usenetDownload struct {
+	usenet  models.UsenetDownload
+	general *general.GeneralService
+}
+
+func (d *usenetDownload) Kind() Kind                     { return KindUsenet }
+func (d *usenetDownload) ID() int64                      { return d.usenet.ID }
+func (d *usenetDownload) Hash() string                   { return d.usenet.Hash }
+func (d *usenetDownload) Name() string                   { return d.usenet.Name }
+func (d *usenetDownload) Size() int64                    { return d.usenet.Size }
+func (d *usenetDownload) Progress() float64              { return d.usenet.Progress }
+func (d *usenetDownload) State() constants.DownloadState { return d.usenet.DownloadState }
+func (d *usenetDownload) Files() []models.File           { return d.usenet.Files }
+
+// Usenet returns the underlying model.
+func (d *usenetDownload) Usenet() models.UsenetDownload { return d.usenet }
+
+func (d *usenetDownload) Pause() error {
+	return d.general.ControlUsenetDownload(d.usenet.ID, constants.ControlUsenetOperationPause)
+}
+
+func (d *usenetDownload) Resume() error {
+	return d.general.ControlUsenetDownload(d.usenet.ID, constants.ControlUsenetOperationResume)
+}
+
+func (d *usenetDownload) Delete() error {
+	return d.general.ControlUsenetDownload(d.usenet.ID, constants.ControlUsenetOperationDelete)
+}
+
+type webDownload struct {
+	web     models.WebDownload
+	general *general.GeneralService
+}
+
+func (d *webDownload) Kind() Kind                     { return KindWebDownload }
+func (d *webDownload) ID() int64                      { return d.web.ID }
+func (d *webDownload) Hash() string                   { return d.web.Hash }
+func (d *webDownload) Name() string                   { return d.web.Name }
+func (d *webDownload) Size() int64                    { return d.web.Size }
+func (d *webDownload) Progress() float64              { return d.web.Progress }
+func (d *webDownload) State() constants.DownloadState { return d.web.DownloadState }
+func (d *webDownload) Files() []models.File           { return d.web.Files }
+
+// WebDownload returns the underlying model.
+func (d *webDownload) WebDownload() models.WebDownload { return d.web }
+
+func (d *webDownload) Pause() error {
+	return d.general.ControlWebDownload(d.web.ID, constants.ControlWebDownloadOperationPause)
+}
+
+func (d *webDownload) Resume() error {
+	return d.general.ControlWebDownload(d.web.ID, constants.ControlWebDownloadOperationResume)
+}
+
+func (d *webDownload) Delete() error {
+	return d.general.ControlWebDownload(d.web.ID, constants.ControlWebDownloadOperationDelete)
+}
@@ -0,0 +1,160 @@
+package library
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+)
+
+// Library lists and finds downloads across every kind TorBox supports.
+type Library struct {
+	general *general.GeneralService
+}
+
+func New(generalService *general.GeneralService) *Library {
+	return &Library{
+		general: generalService,
+	}
+}
+
+// List fetches every kind in parallel. Kinds that fail to list are reported
+// in the returned error alongside whatever could be fetched.
+func (l *Library) List() ([]Download, error) {
+	return l.ListKinds(Kinds...)
+}
+
+func (l *Library) ListKinds(kinds ...Kind) ([]Download, error) {
+	results := make([][]Download, len(kinds))
+	errs := make([]error, len(kinds))
+
+	var wg sync.WaitGroup
+	for i, kind := range kinds {
+		wg.Add(1)
+
+		go func(i int, kind Kind) {
+			defer wg.Done()
+
+			downloads, err := l.ListKind(kind)
+			if err != nil {
+				errs[i] = fmt.Errorf("list %s: %w", kind, err)
+				return
+			}
+
+			results[i] = downloads
+		}(i, kind)
+	}
+
+	wg.Wait()
+
+	var downloads []Download
+	for _, result := range results {
+		downloads = append(downloads, result...)
+	}
+
+	return downloads, errors.Join(errs...)
+}
+
+func (l *Library) ListKind(kind Kind) ([]Download, error) {
+	var downloads []Download
+
+	switch kind {
+	case KindTorrent:
+		torrents, err := l.general.GetActiveTorrents()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range torrents {
+			downloads = append(downloads, &torrentDownload{torrent: t, general: l.general})
+		}
+	case KindQueued:
+		queued, err := l.general.GetQueuedTorrents()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range queued {
+			downloads = append(downloads, &queuedDownload{queued: q, general: l.general})
+		}
+	case KindUsenet:
+		usenetList, err := l.general.GetUsenetList()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range usenetList {
+			downloads = append(downloads, &usenetDownload{usenet: u, general: l.general})
+		}
+	case KindWebDownload:
+		webList, err := l.general.GetWebDownloadList()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range webList {
+			downloads = append(downloads, &webDownload{web: w, general: l.general})
+		}
+	default:
+		return nil, fmt.Errorf("unknown download kind %q", kind)
+	}
+
+	return downloads, nil
+}
+
+// Get returns the download of the given kind and ID.
+func (l *Library) Get(kind Kind, id int64) (Download, error) {
+	downloads, err := l.ListKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range downloads {
+		if d.ID() == id {
+			return d, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s %d: %w", kind, id, torboxerrors.ErrDownloadNotFound)
+}
+
+// Find returns every download with the given ID. IDs are only unique per kind,
+// so more than one match is possible.
+func (l *Library) Find(id int64) ([]Download, error) {
+	return l.filter(func(d Download) bool {
+		return d.ID() == id
+	})
+}
+
+func (l *Library) FindByHash(hash string) ([]Download, error) {
+	return l.filter(func(d Download) bool {
+		return strings.EqualFold(d.Hash(), hash)
+	})
+}
+
+func (l *Library) filter(match func(Download) bool) ([]Download, error) {
+	downloads, err := l.List()
+	if len(downloads) == 0 && err != nil {
+		return nil, err
+	}
+
+	var matches []Download
+	for _, d := range downloads {
+		if match(d) {
+			matches = append(matches, d)
+		}
+	}
+
+	if len(matches) == 0 {
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, torboxerrors.ErrDownloadNotFound
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,134 @@
+package library
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+)
+
+// newTestLibrary serves one download of each kind. Web downloads fail to list
+// when failWeb is set.
+func newTestLibrary(t *testing.T, failWeb bool) *Library {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + constants.PATH_TORRENTS_GET_ACTIVE:
+			w.Write([]byte(`{"success":true,"data":[{"id":1,"hash":"aaa","name":"ubuntu"}]}`))
+		case "/" + constants.PATH_TORRENTS_GET_QUEUED:
+			w.Write([]byte(`{"success":true,"data":[{"id":2,"hash":"bbb","name":"debian"}]}`))
+		case "/" + constants.PATH_USENET_GET_LIST:
+			w.Write([]byte(`{"success":true,"data":[{"id":1,"hash":"ccc","name":"fedora"}]}`))
+		case "/" + constants.PATH_WEBDL_GET_LIST:
+			if failWeb {
+				w.Write([]byte(`{"success":false,"detail":"unavailable"}`))
+				return
+			}
+
+			w.Write([]byte(`{"success":true,"data":[{"id":3,"hash":"ddd","name":"arch"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	service := general.New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	return New(service)
+}
+
+func names(downloads []Download) []string {
+	var result []string
+	for _, d := range downloads {
+		result = append(result, string(d.Kind())+":"+d.Name())
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+func TestLibraryList(t *testing.T) {
+	tests := []struct {
+		name     string
+		failWeb  bool
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "every kind",
+			expected: []string{"queued:debian", "torrent:ubuntu", "usenet:fedora", "webdl:arch"},
+		},
+		{
+			name:     "failed kind is reported with the rest",
+			failWeb:  true,
+			expected: []string{"queued:debian", "torrent:ubuntu", "usenet:fedora"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downloads, err := newTestLibrary(t, tt.failWeb).List()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("List() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := names(downloads); !slices.Equal(got, tt.expected) {
+				t.Errorf("List() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLibraryFind(t *testing.T) {
+	tests := []struct {
+		name     string
+		find     func(l *Library) ([]Download, error)
+		failWeb  bool
+		expected []string
+		wantErr  error
+	}{
+		{
+			name:     "id shared across kinds",
+			find:     func(l *Library) ([]Download, error) { return l.Find(1) },
+			expected: []string{"torrent:ubuntu", "usenet:fedora"},
+		},
+		{
+			name:    "unknown id",
+			find:    func(l *Library) ([]Download, error) { return l.Find(9) },
+			wantErr: torboxerrors.ErrDownloadNotFound,
+		},
+		{
+			name:     "hash ignores case",
+			find:     func(l *Library) ([]Download, error) { return l.FindByHash("CCC") },
+			expected: []string{"usenet:fedora"},
+		},
+		{
+			name:     "match despite a failed kind",
+			find:     func(l *Library) ([]Download, error) { return l.FindByHash("bbb") },
+			failWeb:  true,
+			expected: []string{"queued:debian"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downloads, err := tt.find(newTestLibrary(t, tt.failWeb))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := names(downloads); !slices.Equal(got, tt.expected) {
+				t.Errorf("found %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package library
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/rs/zerolog/log"
+)
+
+type EventType string
+
+const (
+	EventAdded        EventType = "added"
+	EventRemoved      EventType = "removed"
+	EventStateChanged EventType = "state_changed"
+)
+
+// Event describes a change between two polls. Previous is empty for added
+// downloads and Download is the last seen value for removed ones.
+type Event struct {
+	Type     EventType
+	Download Download
+	Previous constants.DownloadState
+}
+
+// WatchFunc receives every download from a poll together with the changes
+// since the previous one. Returning an error stops the watch.
+type WatchFunc func(downloads []Download, events []Event) error
+
+// Watch polls the library every interval until ctx is done. The first poll
+// reports every download as added. Polls that fail are logged and retried on
+// the next tick rather than reported as removals.
+func (l *Library) Watch(ctx context.Context, interval time.Duration, fn WatchFunc) error {
+	known := make(map[string]Download)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		downloads, err := l.List()
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to poll library")
+		} else {
+			events := diff(known, downloads)
+
+			err = fn(downloads, events)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// diff updates known to match downloads and returns what changed.
+func diff(known map[string]Download, downloads []Download) []Event {
+	var events []Event
+
+	seen := make(map[string]bool, len(downloads))
+	for _, d := range downloads {
+		key := downloadKey(d)
+		seen[key] = true
+
+		previous, ok := known[key]
+		switch {
+		case !ok:
+			events = append(events, Event{Type: EventAdded, Download: d})
+		case previous.State() != d.State():
+			events = append(events, Event{Type: EventStateChanged, Download: d, Previous: previous.State()})
+		}
+
+		known[key] = d
+	}
+
+	for key, d := range known {
+		if seen[key] {
+			continue
+		}
+
+		events = append(events, Event{Type: EventRemoved, Download: d, Previous: d.State()})
+		delete(known, key)
+	}
+
+	return events
+}
+
+func downloadKey(d Download) string {
+	return fmt.Sprintf("%s:%d", d.Kind(), d.ID())
+}
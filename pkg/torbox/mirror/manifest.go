@@ -0,0 +1,76 @@
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// manifestName is the file in the target directory recording which files
+// belong to each synced item, so deletes never touch anything else in it.
+const manifestName = ".torbox-mirror.json"
+
+// manifest maps an item name to its synced paths, relative to the target
+// directory.
+type manifest map[string][]string
+
+func loadManifest(targetDir string) (manifest, error) {
+	data, err := os.ReadFile(filepath.Join(targetDir, manifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(manifest), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(manifest)
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mirror manifest: %w", err)
+	}
+
+	return m, nil
+}
+
+func (m manifest) save(targetDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(targetDir, manifestName), data, 0o644)
+}
+
+// record replaces the item's entry with the given local paths that exist on
+// disk.
+func (m manifest) record(targetDir string, name string, paths []string) {
+	var relPaths []string
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		relPath, err := filepath.Rel(targetDir, path)
+		if err != nil {
+			continue
+		}
+
+		relPaths = append(relPaths, filepath.ToSlash(relPath))
+	}
+
+	slices.Sort(relPaths)
+	relPaths = slices.Compact(relPaths)
+
+	if len(relPaths) == 0 {
+		delete(m, name)
+		return
+	}
+
+	m[name] = relPaths
+}
@@ -0,0 +1,325 @@
+package mirror
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+const partialSuffix = ".part"
+
+type Options struct {
+	// Delete removes files an earlier sync recorded for the item that are no
+	// longer part of it. Files that were never part of the item are kept.
+	Delete bool
+
+	// Checksum compares MD5 hashes where TorBox provides them instead of relying on size alone.
+	Checksum bool
+}
+
+type Mirror struct {
+	general    *general.GeneralService
+	httpClient *http.Client
+}
+
+func New(generalService *general.GeneralService) *Mirror {
+	return &Mirror{
+		general: generalService,
+
+		// download links are pre-signed, so files are fetched without the auth transport
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				IdleConnTimeout: 30 * time.Second,
+			},
+		},
+	}
+}
+
+func (m *Mirror) PlanTorrent(t models.Torrent, targetDir string, opts Options) (*Plan, error) {
+	if !t.IsDownloaded() {
+		return nil, fmt.Errorf("torrent %d: %w", t.ID, torboxerrors.ErrDownloadNotFinished)
+	}
+
+	downloadUrl := func(fileId int64) (*string, error) {
+		return m.general.GetDownloadUrl(t.ID, fileId)
+	}
+
+	return buildPlan(t.Name, t.Files, targetDir, opts, downloadUrl)
+}
+
+func (m *Mirror) PlanUsenet(u models.UsenetDownload, targetDir string, opts Options) (*Plan, error) {
+	if u.Progress < 1 {
+		return nil, fmt.Errorf("usenet download %d: %w", u.ID, torboxerrors.ErrDownloadNotFinished)
+	}
+
+	downloadUrl := func(fileId int64) (*string, error) {
+		return m.general.GetUsenetDownloadUrl(u.ID, fileId)
+	}
+
+	return buildPlan(u.Name, u.Files, targetDir, opts, downloadUrl)
+}
+
+func (m *Mirror) PlanWebDownload(w models.WebDownload, targetDir string, opts Options) (*Plan, error) {
+	if w.Progress < 1 {
+		return nil, fmt.Errorf("web download %d: %w", w.ID, torboxerrors.ErrDownloadNotFinished)
+	}
+
+	downloadUrl := func(fileId int64) (*string, error) {
+		return m.general.GetWebDownloadUrl(w.ID, fileId)
+	}
+
+	return buildPlan(w.Name, w.Files, targetDir, opts, downloadUrl)
+}
+
+// Apply executes every download and delete action in the plan, then records
+// the item's files in the target directory's manifest. Failures are collected
+// so a single bad file does not abort the rest of the sync.
+func (m *Mirror) Apply(ctx context.Context, plan *Plan) error {
+	var errs []error
+	defer func() {
+		err := m.saveManifest(plan)
+		if err != nil {
+			log.Error().Err(err).Str("dir", plan.TargetDir).Msg("failed to save mirror manifest")
+		}
+	}()
+
+	for _, action := range plan.Actions {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		var err error
+		switch action.Operation {
+		case OperationDownload:
+			err = m.download(ctx, plan, action)
+		case OperationDelete:
+			log.Info().Str("path", action.LocalPath).Msg("deleting file no longer part of the item")
+			err = os.Remove(action.LocalPath)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", action.Operation, action.LocalPath, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// saveManifest records every file of the item now on disk, along with any
+// earlier file whose delete failed or was not planned, so a later sync with
+// Delete can still remove it.
+func (m *Mirror) saveManifest(plan *Plan) error {
+	err := os.MkdirAll(plan.TargetDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	written, err := loadManifest(plan.TargetDir)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(plan.Actions)+len(plan.recorded))
+	for _, action := range plan.Actions {
+		paths = append(paths, action.LocalPath)
+	}
+
+	for _, relPath := range plan.recorded {
+		paths = append(paths, filepath.Join(plan.TargetDir, filepath.FromSlash(relPath)))
+	}
+
+	written.record(plan.TargetDir, plan.Name, paths)
+
+	return written.save(plan.TargetDir)
+}
+
+func (m *Mirror) download(ctx context.Context, plan *Plan, action Action) error {
+	downloadUrl, err := plan.downloadUrl(action.File.ID)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(action.LocalPath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, *downloadUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("path", action.LocalPath).
+		Int64("size", action.File.Size).
+		Msg("downloading file")
+
+	httpResponse, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("download failed with status: %s", httpResponse.Status)
+	}
+
+	partialPath := action.LocalPath + partialSuffix
+	partialFile, err := os.Create(partialPath)
+	if err != nil {
+		return err
+	}
+
+	hash := md5.New()
+	_, err = io.Copy(io.MultiWriter(partialFile, hash), httpResponse.Body)
+	closeErr := partialFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil && action.File.MD5 != nil && *action.File.MD5 != "" {
+		localMD5 := fmt.Sprintf("%x", hash.Sum(nil))
+		if !strings.EqualFold(localMD5, *action.File.MD5) {
+			err = fmt.Errorf("md5 mismatch: expected %s, got %s", *action.File.MD5, localMD5)
+		}
+	}
+
+	if err != nil {
+		os.Remove(partialPath)
+		return err
+	}
+
+	return os.Rename(partialPath, action.LocalPath)
+}
+
+func buildPlan(name string, files []models.File, targetDir string, opts Options, downloadUrl urlFunc) (*Plan, error) {
+	targetDir, err := filepath.Abs(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Name:      name,
+		TargetDir: targetDir,
+
+		downloadUrl: downloadUrl,
+	}
+
+	wanted := make(map[string]bool, len(files))
+	for i := range files {
+		file := &files[i]
+
+		path, err := localPath(targetDir, *file)
+		if err != nil {
+			return nil, err
+		}
+
+		wanted[path] = true
+
+		operation, reason, err := compareLocal(path, file, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Actions = append(plan.Actions, Action{
+			Operation: operation,
+			File:      file,
+			LocalPath: path,
+			Reason:    reason,
+		})
+	}
+
+	written, err := loadManifest(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.recorded = written[name]
+
+	if !opts.Delete {
+		return plan, nil
+	}
+
+	for _, relPath := range plan.recorded {
+		path := filepath.Join(targetDir, filepath.FromSlash(relPath))
+		if wanted[path] {
+			continue
+		}
+
+		_, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		plan.Actions = append(plan.Actions, Action{
+			Operation: OperationDelete,
+			LocalPath: path,
+			Reason:    "no longer present remotely",
+		})
+	}
+
+	return plan, nil
+}
+
+func compareLocal(path string, file *models.File, opts Options) (Operation, string, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return OperationDownload, "missing locally", nil
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if info.Size() != file.Size {
+		return OperationDownload, fmt.Sprintf("size differs: local %d, remote %d", info.Size(), file.Size), nil
+	}
+
+	if !opts.Checksum || file.MD5 == nil || *file.MD5 == "" {
+		return OperationSkip, "size matches", nil
+	}
+
+	localMD5, err := fileMD5(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !strings.EqualFold(localMD5, *file.MD5) {
+		return OperationDownload, "md5 differs", nil
+	}
+
+	return OperationSkip, "md5 matches", nil
+}
+
+func fileMD5(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
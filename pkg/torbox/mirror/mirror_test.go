@@ -0,0 +1,209 @@
+package mirror
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func md5Of(data string) *string {
+	sum := fmt.Sprintf("%x", md5.Sum([]byte(data)))
+	return &sum
+}
+
+func TestPlanTorrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		local    map[string]string
+		recorded []string
+		file     models.File
+		opts     Options
+		expected map[string]Operation
+	}{
+		{
+			name:     "missing file is downloaded",
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			expected: map[string]Operation{"show/a.mkv": OperationDownload},
+		},
+		{
+			name:     "same size is skipped",
+			local:    map[string]string{"show/a.mkv": "hello"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			expected: map[string]Operation{"show/a.mkv": OperationSkip},
+		},
+		{
+			name:     "different size is downloaded",
+			local:    map[string]string{"show/a.mkv": "hi"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			expected: map[string]Operation{"show/a.mkv": OperationDownload},
+		},
+		{
+			name:     "checksum catches same size changes",
+			local:    map[string]string{"show/a.mkv": "jello"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5, MD5: md5Of("hello")},
+			opts:     Options{Checksum: true},
+			expected: map[string]Operation{"show/a.mkv": OperationDownload},
+		},
+		{
+			name:     "recorded files no longer in the item are deleted",
+			local:    map[string]string{"show/a.mkv": "hello", "show/old.nfo": "x"},
+			recorded: []string{"show/a.mkv", "show/old.nfo"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			opts:     Options{Delete: true},
+			expected: map[string]Operation{
+				"show/a.mkv":   OperationSkip,
+				"show/old.nfo": OperationDelete,
+			},
+		},
+		{
+			name:     "unrecorded files are kept",
+			local:    map[string]string{"show/a.mkv": "hello", "show/notes.txt": "x", "other/b.mkv": "x"},
+			recorded: []string{"show/a.mkv"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			opts:     Options{Delete: true},
+			expected: map[string]Operation{"show/a.mkv": OperationSkip},
+		},
+		{
+			name:     "recorded files are kept without delete",
+			local:    map[string]string{"show/a.mkv": "hello", "show/old.nfo": "x"},
+			recorded: []string{"show/a.mkv", "show/old.nfo"},
+			file:     models.File{ID: 1, AbsolutePath: "show/a.mkv", Size: 5},
+			expected: map[string]Operation{"show/a.mkv": OperationSkip},
+		},
+		{
+			name:     "paths cannot escape the target",
+			file:     models.File{ID: 1, AbsolutePath: "../../etc/passwd", Size: 5},
+			expected: map[string]Operation{"etc/passwd": OperationDownload},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targetDir := t.TempDir()
+			for path, content := range tt.local {
+				localPath := filepath.Join(targetDir, filepath.FromSlash(path))
+				os.MkdirAll(filepath.Dir(localPath), 0o755)
+				os.WriteFile(localPath, []byte(content), 0o644)
+			}
+
+			if tt.recorded != nil {
+				manifest{"show": tt.recorded}.save(targetDir)
+			}
+
+			torrent := models.Torrent{ID: 7, Name: "show", Files: []models.File{tt.file}}
+			torrent.DownloadFinished = true
+
+			plan, err := New(nil).PlanTorrent(torrent, targetDir, tt.opts)
+			if err != nil {
+				t.Fatalf("PlanTorrent() error = %v", err)
+			}
+
+			actual := make(map[string]Operation)
+			for _, action := range plan.Actions {
+				relPath, _ := filepath.Rel(targetDir, action.LocalPath)
+				actual[filepath.ToSlash(relPath)] = action.Operation
+			}
+
+			if !maps.Equal(actual, tt.expected) {
+				t.Errorf("PlanTorrent() actions = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlanTorrentUnfinished(t *testing.T) {
+	_, err := New(nil).PlanTorrent(models.Torrent{ID: 7}, t.TempDir(), Options{})
+	if !errors.Is(err, torboxerrors.ErrDownloadNotFinished) {
+		t.Errorf("PlanTorrent() error = %v, want ErrDownloadNotFinished", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + constants.PATH_TORRENTS_GET_DOWNLOAD_URL:
+			fmt.Fprintf(w, `{"success":true,"data":"%s/files/%s"}`, server.URL, r.URL.Query().Get("file_id"))
+		case "/files/1":
+			w.Write([]byte("hello"))
+		case "/files/2":
+			w.Write([]byte("corrupt"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	service := general.New(http.Client{}, "token")
+	service.BaseURL = server.URL
+
+	targetDir := t.TempDir()
+	os.WriteFile(filepath.Join(targetDir, "notes.txt"), []byte("x"), 0o644)
+	os.WriteFile(filepath.Join(targetDir, "old.mkv"), []byte("x"), 0o644)
+	manifest{"show": {"old.mkv"}, "other": {"c.mkv"}}.save(targetDir)
+
+	torrent := models.Torrent{ID: 7, Name: "show", Files: []models.File{
+		{ID: 1, AbsolutePath: "a.mkv", Size: 5, MD5: md5Of("hello")},
+		{ID: 2, AbsolutePath: "b.mkv", Size: 7, MD5: md5Of("expected")},
+	}}
+	torrent.DownloadFinished = true
+
+	mirror := New(service)
+	plan, err := mirror.PlanTorrent(torrent, targetDir, Options{Delete: true})
+	if err != nil {
+		t.Fatalf("PlanTorrent() error = %v", err)
+	}
+
+	err = mirror.Apply(context.Background(), plan)
+	if err == nil {
+		t.Error("Apply() expected an md5 mismatch error for b.mkv")
+	}
+
+	tests := []struct {
+		path    string
+		content string
+		exists  bool
+	}{
+		{path: "a.mkv", content: "hello", exists: true},
+		{path: "b.mkv"},
+		{path: "b.mkv" + partialSuffix},
+		{path: "notes.txt", content: "x", exists: true},
+		{path: "old.mkv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(targetDir, tt.path))
+			if tt.exists != (err == nil) {
+				t.Fatalf("%s exists = %v, want %v", tt.path, err == nil, tt.exists)
+			}
+
+			if tt.exists && string(data) != tt.content {
+				t.Errorf("%s = %q, want %q", tt.path, data, tt.content)
+			}
+		})
+	}
+
+	written, err := loadManifest(targetDir)
+	if err != nil {
+		t.Fatalf("loadManifest() error = %v", err)
+	}
+
+	expected := manifest{"show": {"a.mkv"}, "other": {"c.mkv"}}
+	if !reflect.DeepEqual(written, expected) {
+		t.Errorf("manifest = %v, want %v", written, expected)
+	}
+}
@@ -0,0 +1,100 @@
+package mirror
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+type Operation string
+
+const (
+	OperationDownload Operation = "download"
+	OperationSkip     Operation = "skip"
+	OperationDelete   Operation = "delete"
+)
+
+type Action struct {
+	Operation Operation
+	File      *models.File
+	LocalPath string
+	Reason    string
+}
+
+type Plan struct {
+	Name      string
+	TargetDir string
+	Actions   []Action
+
+	downloadUrl urlFunc
+
+	// recorded lists the paths an earlier sync wrote for the item, relative to
+	// TargetDir.
+	recorded []string
+}
+
+// urlFunc resolves a signed download URL for a file belonging to the planned item.
+type urlFunc func(fileId int64) (*string, error)
+
+func (p *Plan) Count(operation Operation) int {
+	count := 0
+	for _, action := range p.Actions {
+		if action.Operation == operation {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (p *Plan) DownloadSize() int64 {
+	var size int64
+	for _, action := range p.Actions {
+		if action.Operation == OperationDownload && action.File != nil {
+			size += action.File.Size
+		}
+	}
+
+	return size
+}
+
+// Print writes an rsync style summary of the plan, one line per action.
+func (p *Plan) Print(w io.Writer) {
+	fmt.Fprintf(w, "sync %s -> %s\n", p.Name, p.TargetDir)
+
+	for _, action := range p.Actions {
+		relPath, err := filepath.Rel(p.TargetDir, action.LocalPath)
+		if err != nil {
+			relPath = action.LocalPath
+		}
+
+		fmt.Fprintf(w, "  %-8s %s (%s)\n", action.Operation, relPath, action.Reason)
+	}
+
+	fmt.Fprintf(w, "%d to download (%d bytes), %d up to date, %d to delete\n",
+		p.Count(OperationDownload),
+		p.DownloadSize(),
+		p.Count(OperationSkip),
+		p.Count(OperationDelete),
+	)
+}
+
+// localPath maps a remote file onto the target directory, keeping its
+// AbsolutePath structure while refusing to escape the target directory.
+func localPath(targetDir string, file models.File) (string, error) {
+	remotePath := file.AbsolutePath
+	if remotePath == "" {
+		remotePath = file.Name
+	}
+
+	cleanPath := filepath.Clean("/" + filepath.FromSlash(remotePath))
+	relPath := strings.TrimPrefix(cleanPath, string(filepath.Separator))
+	if relPath == "" || relPath == "." {
+		return "", fmt.Errorf("file %d has no usable path", file.ID)
+	}
+
+	return filepath.Join(targetDir, relPath), nil
+}
@@ -3,8 +3,9 @@ package models
 import "github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
 
 type ControlActiveTorrentRequest struct {
-	TorrentID int64                            `json:"torrent_id"`
+	TorrentID int64                            `json:"torrent_id,omitempty"`
 	Operation constants.ControlActiveOperation `json:"operation"`
+	All       bool                             `json:"all,omitempty"`
 }
 
 type ControlActiveTorrentResponse struct {
@@ -1,28 +1,65 @@
 package models
 
-import "github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+import (
+	"encoding/json"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+)
 
 // Usenet models
 type UsenetDownload struct {
-	ID             int64   `json:"id"`
-	Hash           string  `json:"hash"`
-	Name           string  `json:"name"`
-	Size           int64   `json:"size"`
-	DownloadState  string  `json:"download_state"`
-	DownloadSpeed  float64 `json:"download_speed"`
-	UploadSpeed    float64 `json:"upload_speed"`
-	DownloadedSize int64   `json:"downloaded"`
-	Progress       float64 `json:"progress"`
-	Ratio          float64 `json:"ratio"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
-	Files          []File  `json:"files"`
+	ID             int64                   `json:"id"`
+	Hash           string                  `json:"hash"`
+	Name           string                  `json:"name"`
+	OriginalURL    string                  `json:"original_url"`
+	Size           int64                   `json:"size"`
+	DownloadState  constants.DownloadState `json:"download_state"`
+	DownloadSpeed  float64                 `json:"download_speed"`
+	UploadSpeed    float64                 `json:"upload_speed"`
+	DownloadedSize int64                   `json:"downloaded"`
+	Progress       float64                 `json:"progress"`
+	Ratio          float64                 `json:"ratio"`
+	CreatedAt      Time                    `json:"created_at"`
+	UpdatedAt      Time                    `json:"updated_at"`
+	Files          []File                  `json:"files"`
+}
+
+func (u *UsenetDownload) UnmarshalJSON(d []byte) error {
+	type Alias UsenetDownload
+	type Aux struct {
+		*Alias
+
+		UsenetDownloadID *int64 `json:"usenetdownload_id"`
+	}
+
+	aux := &Aux{
+		Alias: (*Alias)(u),
+	}
+
+	err := json.Unmarshal(d, &aux)
+	if err != nil {
+		return err
+	}
+
+	if aux.UsenetDownloadID != nil {
+		u.ID = *aux.UsenetDownloadID
+	}
+
+	return nil
 }
 
 type CreateUsenetRequest struct {
-	Link     string  `json:"link"`
-	Name     *string `json:"name,omitempty"`
-	AsQueued *bool   `json:"as_queued,omitempty"`
+	Link string `json:"link,omitempty"`
+
+	// File holds NZB contents to upload instead of Link, FileName is the name
+	// sent with the upload.
+	File     []byte `json:"-"`
+	FileName string `json:"-"`
+
+	Name           *string                   `json:"name,omitempty"`
+	Password       *string                   `json:"password,omitempty"`
+	PostProcessing *constants.PostProcessing `json:"post_processing,omitempty"`
+	AsQueued       *bool                     `json:"as_queued,omitempty"`
 }
 
 type CreateUsenetResponse struct {
@@ -36,29 +73,60 @@ type GetUsenetListResponse struct {
 }
 
 type ControlUsenetRequest struct {
-	UsenetID  int64                          `json:"usenet_id"`
+	UsenetID  int64                            `json:"usenet_id,omitempty"`
 	Operation constants.ControlUsenetOperation `json:"operation"`
+	All       bool                             `json:"all,omitempty"`
 }
 
 // Web Download models
 type WebDownload struct {
-	ID             int64   `json:"id"`
-	Hash           string  `json:"hash"`
-	Name           string  `json:"name"`
-	Size           int64   `json:"size"`
-	DownloadState  string  `json:"download_state"`
-	DownloadSpeed  float64 `json:"download_speed"`
-	DownloadedSize int64   `json:"downloaded"`
-	Progress       float64 `json:"progress"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
-	Files          []File  `json:"files"`
+	ID             int64                   `json:"id"`
+	Hash           string                  `json:"hash"`
+	Name           string                  `json:"name"`
+	OriginalURL    string                  `json:"original_url"`
+	Size           int64                   `json:"size"`
+	DownloadState  constants.DownloadState `json:"download_state"`
+	DownloadSpeed  float64                 `json:"download_speed"`
+	DownloadedSize int64                   `json:"downloaded"`
+	Progress       float64                 `json:"progress"`
+	CreatedAt      Time                    `json:"created_at"`
+	UpdatedAt      Time                    `json:"updated_at"`
+	Files          []File                  `json:"files"`
+}
+
+func (w *WebDownload) UnmarshalJSON(d []byte) error {
+	type Alias WebDownload
+	type Aux struct {
+		*Alias
+
+		WebDownloadID *int64 `json:"webdownload_id"`
+	}
+
+	aux := &Aux{
+		Alias: (*Alias)(w),
+	}
+
+	err := json.Unmarshal(d, &aux)
+	if err != nil {
+		return err
+	}
+
+	if aux.WebDownloadID != nil {
+		w.ID = *aux.WebDownloadID
+	}
+
+	return nil
 }
 
 type CreateWebDownloadRequest struct {
 	Link     string  `json:"link"`
 	Name     *string `json:"name,omitempty"`
 	AsQueued *bool   `json:"as_queued,omitempty"`
+
+	// CheckHoster rejects links that are not from a supported, online hoster
+	// before submitting them. It is off by default because TorBox also accepts
+	// plain direct download links from any host.
+	CheckHoster bool `json:"-"`
 }
 
 type CreateWebDownloadResponse struct {
@@ -66,7 +134,41 @@ type CreateWebDownloadResponse struct {
 	Data *WebDownload `json:"data"`
 }
 
+type GetWebDownloadListResponse struct {
+	BaseResponse
+	Data []WebDownload `json:"data"`
+}
+
 type ControlWebDownloadRequest struct {
-	WebID     int64                                 `json:"web_id"`
+	WebID     int64                                 `json:"web_id,omitempty"`
 	Operation constants.ControlWebDownloadOperation `json:"operation"`
+	All       bool                                  `json:"all,omitempty"`
+}
+
+// ListOptions pages through list endpoints, a zero Limit uses the API default.
+type ListOptions struct {
+	Offset int
+	Limit  int
+}
+
+type Hoster struct {
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+	URL     string   `json:"url"`
+	Icon    string   `json:"icon"`
+	Type    string   `json:"type"`
+	Note    *string  `json:"note"`
+
+	// Status is false while TorBox cannot download from the hoster.
+	Status bool `json:"status"`
+
+	DailyLinkLimit      int64 `json:"daily_link_limit"`
+	DailyLinkUsed       int64 `json:"daily_link_used"`
+	DailyBandwidthLimit int64 `json:"daily_bandwidth_limit"`
+	DailyBandwidthUsed  int64 `json:"daily_bandwidth_used"`
+}
+
+type GetHostersResponse struct {
+	BaseResponse
+	Data []Hoster `json:"data"`
 }
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+)
+
+// NotificationFeed is the RSS document served by the notifications RSS endpoint.
+type NotificationFeed struct {
+	XMLName xml.Name `xml:"rss"`
+	Channel struct {
+		Title       string                 `xml:"title"`
+		Link        string                 `xml:"link"`
+		Description string                 `xml:"description"`
+		Items       []NotificationFeedItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Items returns the feed's entries, newest first as served.
+func (f *NotificationFeed) Items() []NotificationFeedItem {
+	return f.Channel.Items
+}
+
+type NotificationFeedItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	PubDate     Time   `xml:"pubDate"`
+	Category    string `xml:"category"`
+	Description string `xml:"description"`
+}
+
+// NotificationFilter selects notifications for a generated feed. The zero
+// value matches everything.
+type NotificationFilter struct {
+	// Types keeps only notifications of these types.
+	Types []constants.NotificationType
+	// Since drops notifications created before it.
+	Since time.Time
+	// UnreadOnly drops notifications already marked read.
+	UnreadOnly bool
+	// Query keeps notifications whose title or message contains it, ignoring case.
+	Query string
+}
+
+func (f NotificationFilter) Match(n Notification) bool {
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if strings.EqualFold(string(t), string(n.Type)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() && n.CreatedAt.Before(f.Since) {
+		return false
+	}
+
+	if f.UnreadOnly && n.Read {
+		return false
+	}
+
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(n.Title), query) && !strings.Contains(strings.ToLower(n.Message), query) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -9,7 +9,7 @@ type GetQueuedTorrentsResponse struct {
 
 type QueuedDownload struct {
 	ID          int64   `json:"id"`
-	CreatedAt   string  `json:"created_at"`
+	CreatedAt   Time    `json:"created_at"`
 	Magnet      string  `json:"magnet"`
 	TorrentFile *string `json:"torrent_file"`
 	Hash        string  `json:"hash"`
@@ -18,8 +18,9 @@ type QueuedDownload struct {
 }
 
 type ControlQueuedTorrentRequest struct {
-	QueuedId  int64                            `json:"queued_id"`
+	QueuedId  int64                            `json:"queued_id,omitempty"`
 	Operation constants.ControlQueuedOperation `json:"operation"`
+	All       bool                             `json:"all,omitempty"`
 }
 
 type ControlQueuedTorrentResponse struct {
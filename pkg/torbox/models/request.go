@@ -1,8 +1,11 @@
 package models
 
 import (
+	"time"
+
 	"github.com/dylanmazurek/go-torbox/pkg/magnet"
 	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torrent"
 )
 
 type GetActiveTorrentsResponse struct {
@@ -42,9 +45,11 @@ type GetTorrentResponse struct {
 	} `json:"data"`
 }
 
-type CheckCachedResponse struct {
+// CheckCachedListResponse is returned by the cache checks when they are
+// asked for format=list, which yields one entry per cached hash.
+type CheckCachedListResponse struct {
 	BaseResponse
-	Data *CacheCheckResponse `json:"data"`
+	Data []CacheCheckResponse `json:"data"`
 }
 
 type CacheCheckResponse struct {
@@ -54,6 +59,18 @@ type CacheCheckResponse struct {
 	Cached bool   `json:"cached"`
 }
 
+// TorrentInfoRequest identifies the torrent to preview by one of Hash, Magnet
+// or File, checked in that order.
+type TorrentInfoRequest struct {
+	Hash   string
+	Magnet *magnet.Magnet
+	File   []byte
+
+	// Timeout is how long TorBox waits for metadata from peers, zero uses the
+	// API default. It is still bounded by the HTTP client's own timeout.
+	Timeout time.Duration
+}
+
 type TorrentInfoResponse struct {
 	BaseResponse
 	Data *Torrent `json:"data"`
@@ -64,6 +81,17 @@ type ExportDataResponse struct {
 	Data string `json:"data"`
 }
 
+type TorrentExport struct {
+	Format constants.ExportFormat
+
+	// Magnet is set for magnet exports.
+	Magnet *magnet.Magnet
+
+	// Data holds the raw .torrent file and Torrent its parsed form, for file exports.
+	Data    []byte
+	Torrent *torrent.Torrent
+}
+
 type SearchTorrentsResponse struct {
 	BaseResponse
 	Data []Torrent `json:"data"`
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+)
+
+// UserSettings are the account wide defaults applied to new downloads and
+// notifications.
+type UserSettings struct {
+	// SeedTorrents is the seeding behaviour new torrents get unless the
+	// request sets its own.
+	SeedTorrents constants.SeedSetting `json:"seed_torrents"`
+	// AllowZipped lets TorBox zip downloads with many files.
+	AllowZipped bool `json:"allow_zipped"`
+	// PreferredLocation is the server region download links are served from,
+	// empty picks the closest.
+	PreferredLocation string `json:"preferred_location"`
+	// ProxyDownloads routes download links through TorBox's CDN instead of
+	// redirecting to the storage server.
+	ProxyDownloads bool `json:"proxy_downloads"`
+
+	EmailNotifications bool   `json:"email_notifications"`
+	WebNotifications   bool   `json:"web_notifications"`
+	RSSNotifications   bool   `json:"rss_notifications"`
+	WebhookURL         string `json:"webhook_url"`
+	DiscordWebhookURL  string `json:"discord_webhook_url"`
+}
+
+func (s *UserSettings) UnmarshalJSON(d []byte) error {
+	type Alias UserSettings
+
+	return json.Unmarshal(d, (*Alias)(s))
+}
+
+type GetUserSettingsResponse struct {
+	BaseResponse
+	Data UserSettings `json:"data"`
+}
+
+// UpdateUserSettingsRequest changes only the settings that are set.
+type UpdateUserSettingsRequest struct {
+	SeedTorrents      *constants.SeedSetting `json:"seed_torrents,omitempty"`
+	AllowZipped       *bool                  `json:"allow_zipped,omitempty"`
+	PreferredLocation *string                `json:"preferred_location,omitempty"`
+	ProxyDownloads    *bool                  `json:"proxy_downloads,omitempty"`
+
+	EmailNotifications *bool   `json:"email_notifications,omitempty"`
+	WebNotifications   *bool   `json:"web_notifications,omitempty"`
+	RSSNotifications   *bool   `json:"rss_notifications,omitempty"`
+	WebhookURL         *string `json:"webhook_url,omitempty"`
+	DiscordWebhookURL  *string `json:"discord_webhook_url,omitempty"`
+}
+
+// Validate checks the seeding mode and webhook URLs before they are sent.
+// An empty URL clears the webhook.
+func (r UpdateUserSettingsRequest) Validate() error {
+	if r.SeedTorrents != nil {
+		switch *r.SeedTorrents {
+		case constants.Auto, constants.Seed, constants.NoSeed:
+		default:
+			return fmt.Errorf("%w: unknown seed_torrents %d", torboxerrors.ErrInvalidSettings, *r.SeedTorrents)
+		}
+	}
+
+	webhooks := map[string]*string{
+		"webhook_url":         r.WebhookURL,
+		"discord_webhook_url": r.DiscordWebhookURL,
+	}
+
+	for name, value := range webhooks {
+		if value == nil || *value == "" {
+			continue
+		}
+
+		parsed, err := url.Parse(*value)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return fmt.Errorf("%w: %s must be an http(s) URL", torboxerrors.ErrInvalidSettings, name)
+		}
+	}
+
+	return nil
+}
+
+type Subscription struct {
+	ID        int64          `json:"id"`
+	Plan      constants.Plan `json:"plan"`
+	Status    string         `json:"status"`
+	Provider  string         `json:"provider"`
+	Amount    float64        `json:"amount"`
+	Currency  string         `json:"currency"`
+	Interval  string         `json:"interval"`
+	CreatedAt Time           `json:"created_at"`
+	RenewsAt  Time           `json:"renews_at"`
+}
+
+type GetSubscriptionsResponse struct {
+	BaseResponse
+	Data []Subscription `json:"data"`
+}
+
+type Transaction struct {
+	ID          int64          `json:"id"`
+	Type        string         `json:"type"`
+	Plan        constants.Plan `json:"plan"`
+	Amount      float64        `json:"amount"`
+	Currency    string         `json:"currency"`
+	Description string         `json:"description"`
+	CreatedAt   Time           `json:"created_at"`
+}
+
+type GetTransactionsResponse struct {
+	BaseResponse
+	Data []Transaction `json:"data"`
+}
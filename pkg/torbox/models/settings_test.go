@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+)
+
+func TestUpdateUserSettingsRequestValidate(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	seed := func(s constants.SeedSetting) *constants.SeedSetting { return &s }
+
+	tests := []struct {
+		name    string
+		request UpdateUserSettingsRequest
+		wantErr bool
+	}{
+		{
+			name:    "empty request",
+			request: UpdateUserSettingsRequest{},
+		},
+		{
+			name: "valid settings",
+			request: UpdateUserSettingsRequest{
+				SeedTorrents:      seed(constants.NoSeed),
+				WebhookURL:        ptr("https://hooks.example.com/torbox"),
+				DiscordWebhookURL: ptr("http://discord.example.com/api/webhooks/1"),
+			},
+		},
+		{
+			name:    "empty webhook clears it",
+			request: UpdateUserSettingsRequest{WebhookURL: ptr(""), DiscordWebhookURL: ptr("")},
+		},
+		{
+			name:    "unknown seed setting",
+			request: UpdateUserSettingsRequest{SeedTorrents: seed(7)},
+			wantErr: true,
+		},
+		{
+			name:    "zero seed setting",
+			request: UpdateUserSettingsRequest{SeedTorrents: seed(0)},
+			wantErr: true,
+		},
+		{
+			name:    "non http webhook",
+			request: UpdateUserSettingsRequest{WebhookURL: ptr("ftp://hooks.example.com/torbox")},
+			wantErr: true,
+		},
+		{
+			name:    "discord webhook without a host",
+			request: UpdateUserSettingsRequest{DiscordWebhookURL: ptr("https:///api/webhooks/1")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, torboxerrors.ErrInvalidSettings) {
+				t.Errorf("Validate() error = %v, expected ErrInvalidSettings", err)
+			}
+		})
+	}
+}
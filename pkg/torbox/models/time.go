@@ -0,0 +1,109 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timeLayouts are tried in order. RFC3339Nano covers whole and fractional
+// seconds with a Z or numeric offset, the rest cover timestamps the API sends
+// without a zone, which are treated as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// feedTimeLayouts cover the RFC 822 style dates used by RSS pubDate elements.
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+}
+
+// Time is a timestamp returned by the TorBox API. Null and empty strings
+// decode to the zero value, which marshals back to null.
+type Time struct {
+	time.Time
+}
+
+func NewTime(t time.Time) Time {
+	return Time{Time: t}
+}
+
+func ParseTime(value string) (Time, error) {
+	for _, layout := range timeLayouts {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return Time{Time: parsed}, nil
+		}
+	}
+
+	return Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+func (t Time) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.Time.Format(time.RFC3339Nano))
+}
+
+func (t *Time) UnmarshalJSON(d []byte) error {
+	if bytes.Equal(d, []byte("null")) {
+		*t = Time{}
+		return nil
+	}
+
+	var value string
+	err := json.Unmarshal(d, &value)
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := ParseTime(value)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+
+	return nil
+}
+
+// UnmarshalText decodes timestamps from XML feeds, which may use either the
+// API's layouts or RSS pubDate dates.
+func (t *Time) UnmarshalText(d []byte) error {
+	value := strings.TrimSpace(string(d))
+	if value == "" {
+		*t = Time{}
+		return nil
+	}
+
+	parsed, err := ParseTime(value)
+	if err == nil {
+		*t = parsed
+		return nil
+	}
+
+	for _, layout := range feedTimeLayouts {
+		feedTime, feedErr := time.Parse(layout, value)
+		if feedErr == nil {
+			*t = Time{Time: feedTime}
+			return nil
+		}
+	}
+
+	return err
+}
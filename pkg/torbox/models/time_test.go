@@ -0,0 +1,120 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantErr  bool
+		expected time.Time
+	}{
+		{
+			name:     "utc without fraction",
+			input:    `"2024-05-01T10:00:00Z"`,
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "fractional seconds",
+			input:    `"2024-05-01T10:00:00.123456Z"`,
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 123456000, time.UTC),
+		},
+		{
+			name:     "numeric offset",
+			input:    `"2024-05-01T12:00:00.5+02:00"`,
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 500000000, time.UTC),
+		},
+		{
+			name:     "no zone",
+			input:    `"2024-05-01T10:00:00.25"`,
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 250000000, time.UTC),
+		},
+		{
+			name:     "space separated",
+			input:    `"2024-05-01 10:00:00"`,
+			expected: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "null",
+			input: `null`,
+		},
+		{
+			name:  "empty string",
+			input: `""`,
+		},
+		{
+			name:    "invalid",
+			input:   `"yesterday"`,
+			wantErr: true,
+		},
+		{
+			name:    "not a string",
+			input:   `12345`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Time
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !got.Equal(tt.expected) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got.Time, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	type wrapper struct {
+		At    Time `json:"at"`
+		Unset Time `json:"unset"`
+	}
+
+	input := `{"at":"2024-05-01T12:00:00.5+02:00","unset":null}`
+
+	var decoded wrapper
+	err := json.Unmarshal([]byte(input), &decoded)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(encoded) != input {
+		t.Errorf("Marshal() = %s, want %s", encoded, input)
+	}
+}
+
+func TestTorrentUnmarshalTimes(t *testing.T) {
+	input := `{"id":1,"created_at":"2024-05-01T10:00:00.123Z","updated_at":"2024-05-02T10:00:00+00:00","expires_at":null}`
+
+	var got Torrent
+	err := json.Unmarshal([]byte(input), &got)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.CreatedAt.IsZero() || got.UpdatedAt.IsZero() {
+		t.Errorf("expected created_at and updated_at to be set, got %v and %v", got.CreatedAt, got.UpdatedAt)
+	}
+
+	if !got.ExpiresAt.IsZero() {
+		t.Errorf("expected expires_at to be zero, got %v", got.ExpiresAt)
+	}
+}
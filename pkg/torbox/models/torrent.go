@@ -1,8 +1,8 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
-	"time"
 )
 
 type Torrent struct {
@@ -25,9 +25,9 @@ type Torrent struct {
 	AllowZipped bool   `json:"allow_zipped"`
 	ShortName   string `json:"short_name"`
 
-	CreatedAt *time.Time `json:"-"`
-	UpdatedAt *time.Time `json:"-"`
-	ExpiresAt *time.Time `json:"-"`
+	CreatedAt Time `json:"created_at"`
+	UpdatedAt Time `json:"updated_at"`
+	ExpiresAt Time `json:"expires_at"`
 
 	Files []File `json:"-"`
 }
@@ -37,17 +37,17 @@ func (t *Torrent) IsDownloaded() bool {
 }
 
 func (t *Torrent) UnmarshalJSON(d []byte) error {
+	if bytes.Equal(d, []byte("null")) {
+		return nil
+	}
+
 	type Alias Torrent
 	type Aux struct {
 		*Alias
 
-		CreatedAtStr string `json:"created_at"`
-		UpdatedAtStr string `json:"updated_at"`
-		ExpiresAtStr string `json:"expires_at"`
-
 		TorrentID *int64 `json:"torrent_id"`
 		QueuedID  *int64 `json:"queued_id"`
-		Files     any    `json:"files"`
+		Files     []File `json:"files"`
 	}
 
 	aux := &Aux{
@@ -59,33 +59,6 @@ func (t *Torrent) UnmarshalJSON(d []byte) error {
 		return err
 	}
 
-	if aux.CreatedAtStr != "" {
-		createdAt, err := time.Parse("2006-01-02T15:04:05Z", aux.CreatedAtStr)
-		if err != nil {
-			return err
-		}
-
-		t.CreatedAt = &createdAt
-	}
-
-	if aux.UpdatedAtStr != "" {
-		updatedAt, err := time.Parse("2006-01-02T15:04:05Z", aux.UpdatedAtStr)
-		if err != nil {
-			return err
-		}
-
-		t.UpdatedAt = &updatedAt
-	}
-
-	if aux.ExpiresAtStr != "" {
-		expiresAt, err := time.Parse("2006-01-02T15:04:05Z", aux.ExpiresAtStr)
-		if err != nil {
-			return err
-		}
-
-		t.ExpiresAt = &expiresAt
-	}
-
 	if aux.TorrentID != nil {
 		t.ID = *aux.TorrentID
 	}
@@ -94,9 +67,9 @@ func (t *Torrent) UnmarshalJSON(d []byte) error {
 		t.ID = *aux.QueuedID
 	}
 
-	if aux.Files == nil {
-		return nil
+	if aux.Files != nil {
+		t.Files = aux.Files
 	}
 
-	return err
+	return nil
 }
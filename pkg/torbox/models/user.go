@@ -1,22 +1,32 @@
 package models
 
-import "github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/rs/zerolog/log"
+)
 
 // User models
 type User struct {
-	ID              int64   `json:"id"`
-	Email           string  `json:"email"`
-	Plan            string  `json:"plan"`
-	PremiumExpiry   string  `json:"premium_expiry"`
-	CooldownUntil   string  `json:"cooldown_until"`
-	Auth0ID         string  `json:"auth0_id"`
-	TotalDownloaded int64   `json:"total_downloaded"`
-	TotalUploaded   int64   `json:"total_uploaded"`
-	Customer        string  `json:"customer"`
-	Server          int     `json:"server"`
-	IsSubscribed    bool    `json:"is_subscribed"`
-	UserReferral    string  `json:"user_referral"`
-	BaseEmail       *string `json:"base_email,omitempty"`
+	ID              int64          `json:"id"`
+	Email           string         `json:"email"`
+	Plan            constants.Plan `json:"plan"`
+	PremiumExpiry   Time           `json:"premium_expiry"`
+	CooldownUntil   Time           `json:"cooldown_until"`
+	Auth0ID         string         `json:"auth0_id"`
+	TotalDownloaded int64          `json:"total_downloaded"`
+	TotalUploaded   int64          `json:"total_uploaded"`
+	Customer        string         `json:"customer"`
+	Server          int            `json:"server"`
+	IsSubscribed    bool           `json:"is_subscribed"`
+	UserReferral    string         `json:"user_referral"`
+	BaseEmail       *string        `json:"base_email,omitempty"`
 }
 
 type GetUserResponse struct {
@@ -37,12 +47,12 @@ type AddReferralRequest struct {
 
 // Notification models
 type Notification struct {
-	ID        int64  `json:"id"`
-	Type      string `json:"type"`
-	Title     string `json:"title"`
-	Message   string `json:"message"`
-	Read      bool   `json:"read"`
-	CreatedAt string `json:"created_at"`
+	ID        int64                      `json:"id"`
+	Type      constants.NotificationType `json:"type"`
+	Title     string                     `json:"title"`
+	Message   string                     `json:"message"`
+	Read      bool                       `json:"read"`
+	CreatedAt Time                       `json:"created_at"`
 }
 
 type GetNotificationsResponse struct {
@@ -52,19 +62,110 @@ type GetNotificationsResponse struct {
 
 // RSS models
 type RSSFeed struct {
+	RSSRules
+
 	ID        int64  `json:"id"`
 	URL       string `json:"url"`
 	Name      string `json:"name"`
 	Enabled   bool   `json:"enabled"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	CreatedAt Time   `json:"created_at"`
+	UpdatedAt Time   `json:"updated_at"`
+}
+
+// RSSRules decide which feed items TorBox adds and how. Nil fields are left
+// at the API default when adding and unchanged when modifying.
+type RSSRules struct {
+	// ScanInterval is how often the feed is checked, in minutes.
+	ScanInterval *int `json:"scan_interval,omitempty"`
+
+	// DoRegex must match an item's title for it to be added, DontRegex must not.
+	DoRegex   *string `json:"do_regex,omitempty"`
+	DontRegex *string `json:"dont_regex,omitempty"`
+
+	// DontOlderThan skips items published more than this many days ago.
+	DontOlderThan *int `json:"dont_older_than,omitempty"`
+
+	// PassCheck adds items even if they were added before.
+	PassCheck *bool `json:"pass_check,omitempty"`
+
+	TorrentSeeding *constants.SeedSetting `json:"torrent_seeding,omitempty"`
+	RSSType        *constants.RSSType     `json:"rss_type,omitempty"`
+}
+
+// Validate checks the regexes, numeric fields and type, so mistakes are caught
+// before the feed is sent to TorBox. Patterns using syntax the server accepts
+// but Go's RE2 does not, such as lookarounds and backreferences, are only
+// logged as a warning.
+func (r RSSRules) Validate() error {
+	if r.DoRegex != nil {
+		err := validateRSSRegex("do_regex", *r.DoRegex)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.DontRegex != nil {
+		err := validateRSSRegex("dont_regex", *r.DontRegex)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.ScanInterval != nil && *r.ScanInterval <= 0 {
+		return fmt.Errorf("%w: scan_interval must be positive", torboxerrors.ErrInvalidRSSRule)
+	}
+
+	if r.DontOlderThan != nil && *r.DontOlderThan < 0 {
+		return fmt.Errorf("%w: dont_older_than must not be negative", torboxerrors.ErrInvalidRSSRule)
+	}
+
+	if r.RSSType != nil {
+		switch *r.RSSType {
+		case constants.RSSTypeTorrent, constants.RSSTypeUsenet, constants.RSSTypeWebDownload:
+		default:
+			return fmt.Errorf("%w: unknown rss_type %q", torboxerrors.ErrInvalidRSSRule, *r.RSSType)
+		}
+	}
+
+	return nil
+}
+
+// validateRSSRegex rejects patterns with real syntax errors, such as an
+// unbalanced parenthesis or bracket, and lets through syntax RE2 leaves out.
+func validateRSSRegex(field string, pattern string) error {
+	_, err := regexp.Compile(pattern)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		switch syntaxErr.Code {
+		case syntax.ErrInvalidPerlOp, syntax.ErrInvalidNamedCapture, syntax.ErrInvalidEscape, syntax.ErrInvalidRepeatOp:
+			log.Warn().
+				Str("field", field).
+				Str("pattern", pattern).
+				Msg("rss regex uses syntax RE2 does not support, leaving it to the server")
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: invalid %s: %v", torboxerrors.ErrInvalidRSSRule, field, err)
 }
 
 type AddRSSRequest struct {
+	RSSRules
+
 	URL  string `json:"url"`
 	Name string `json:"name"`
 }
 
+type GetRSSFeedsResponse struct {
+	BaseResponse
+	Data []RSSFeed `json:"data"`
+}
+
 type AddRSSResponse struct {
 	BaseResponse
 	Data *RSSFeed `json:"data"`
@@ -76,12 +177,32 @@ type ControlRSSRequest struct {
 }
 
 type ModifyRSSRequest struct {
+	RSSRules
+
 	RSSID   int64   `json:"rss_id"`
 	URL     *string `json:"url,omitempty"`
 	Name    *string `json:"name,omitempty"`
 	Enabled *bool   `json:"enabled,omitempty"`
 }
 
+type RSSFeedItem struct {
+	ID        int64  `json:"id"`
+	RSSFeedID int64  `json:"rss_feed_id"`
+	Title     string `json:"title"`
+	Link      string `json:"link"`
+	Hash      string `json:"hash"`
+	Size      int64  `json:"size"`
+	Published Time   `json:"published"`
+
+	// Added is true once TorBox has added the item as a download.
+	Added bool `json:"added"`
+}
+
+type GetRSSFeedItemsResponse struct {
+	BaseResponse
+	Data []RSSFeedItem `json:"data"`
+}
+
 type ModifyRSSResponse struct {
 	BaseResponse
 	Data *RSSFeed `json:"data"`
@@ -91,18 +212,80 @@ type ModifyRSSResponse struct {
 type IntegrationAuthRequest struct {
 	Code   string `json:"code,omitempty"`
 	APIKey string `json:"api_key,omitempty"`
+
+	// CodeVerifier and RedirectURI complete a PKCE flow started by the client.
+	CodeVerifier string `json:"code_verifier,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
+}
+
+// IntegrationStatus reports whether a provider is linked to the account.
+// Info is filled in from the provider registry.
+type IntegrationStatus struct {
+	Provider     constants.IntegrationProvider `json:"provider"`
+	Authorized   bool                          `json:"authorized"`
+	Account      string                        `json:"account"`
+	AuthorizedAt Time                          `json:"authorized_at"`
+
+	Info constants.IntegrationProviderInfo `json:"-"`
+}
+
+// UnmarshalJSON decodes with encoding/json, as marshmallow leaves named string
+// fields such as Provider empty.
+func (s *IntegrationStatus) UnmarshalJSON(d []byte) error {
+	type Alias IntegrationStatus
+
+	return json.Unmarshal(d, (*Alias)(s))
+}
+
+type GetIntegrationStatusResponse struct {
+	BaseResponse
+	Data []IntegrationStatus `json:"data"`
+}
+
+// IntegrationTransferRequest queues an upload of a finished download. A nil
+// FileID sends every file, zipped together when Zip is set.
+type IntegrationTransferRequest struct {
+	ID     int64                         `json:"id"`
+	Type   constants.IntegrationItemType `json:"type"`
+	FileID *int64                        `json:"file_id,omitempty"`
+	Zip    bool                          `json:"zip"`
 }
 
 type IntegrationJob struct {
-	ID          int64  `json:"id"`
-	Type        string `json:"type"`
-	Status      string `json:"status"`
-	FileName    string `json:"file_name"`
-	FileSize    int64  `json:"file_size"`
-	Progress    float64 `json:"progress"`
-	Destination string `json:"destination"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          int64                          `json:"id"`
+	Type        string                         `json:"type"`
+	Status      constants.IntegrationJobStatus `json:"status"`
+	FileName    string                         `json:"file_name"`
+	FileSize    int64                          `json:"file_size"`
+	Progress    float64                        `json:"progress"`
+	Destination string                         `json:"destination"`
+	Detail      string                         `json:"detail"`
+	CreatedAt   Time                           `json:"created_at"`
+	UpdatedAt   Time                           `json:"updated_at"`
+}
+
+func (j *IntegrationJob) UnmarshalJSON(d []byte) error {
+	type Alias IntegrationJob
+	type Aux struct {
+		*Alias
+
+		JobID *int64 `json:"job_id"`
+	}
+
+	aux := &Aux{
+		Alias: (*Alias)(j),
+	}
+
+	err := json.Unmarshal(d, &aux)
+	if err != nil {
+		return err
+	}
+
+	if aux.JobID != nil {
+		j.ID = *aux.JobID
+	}
+
+	return nil
 }
 
 type GetIntegrationJobsResponse struct {
@@ -110,19 +293,24 @@ type GetIntegrationJobsResponse struct {
 	Data []IntegrationJob `json:"data"`
 }
 
+type GetIntegrationJobResponse struct {
+	BaseResponse
+	Data IntegrationJob `json:"data"`
+}
+
 // Stats models
 type Stats struct {
-	TotalDownloaded int64   `json:"total_downloaded"`
-	TotalUploaded   int64   `json:"total_uploaded"`
-	TotalTorrents   int     `json:"total_torrents"`
-	ActiveTorrents  int     `json:"active_torrents"`
-	QueuedTorrents  int     `json:"queued_torrents"`
-	TotalUsenet     int     `json:"total_usenet"`
-	TotalWebDL      int     `json:"total_webdl"`
-	AvailableSpace  int64   `json:"available_space"`
-	UsedSpace       int64   `json:"used_space"`
-	Plan            string  `json:"plan"`
-	PremiumExpiry   *string `json:"premium_expiry,omitempty"`
+	TotalDownloaded int64          `json:"total_downloaded"`
+	TotalUploaded   int64          `json:"total_uploaded"`
+	TotalTorrents   int            `json:"total_torrents"`
+	ActiveTorrents  int            `json:"active_torrents"`
+	QueuedTorrents  int            `json:"queued_torrents"`
+	TotalUsenet     int            `json:"total_usenet"`
+	TotalWebDL      int            `json:"total_webdl"`
+	AvailableSpace  int64          `json:"available_space"`
+	UsedSpace       int64          `json:"used_space"`
+	Plan            constants.Plan `json:"plan"`
+	PremiumExpiry   Time           `json:"premium_expiry"`
 }
 
 type GetStatsResponse struct {
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+)
+
+func TestRSSRulesValidate(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	interval := func(i int) *int { return &i }
+	rssType := func(t constants.RSSType) *constants.RSSType { return &t }
+
+	tests := []struct {
+		name    string
+		rules   RSSRules
+		wantErr bool
+	}{
+		{
+			name:  "empty rules",
+			rules: RSSRules{},
+		},
+		{
+			name: "valid rules",
+			rules: RSSRules{
+				DoRegex:      ptr(`(?i)1080p.*web-?dl`),
+				DontRegex:    ptr(`\bCAM\b`),
+				ScanInterval: interval(30),
+				RSSType:      rssType(constants.RSSTypeUsenet),
+			},
+		},
+		{
+			name:  "lookarounds are left to the server",
+			rules: RSSRules{DoRegex: ptr(`(?<!CAM\.)1080p`), DontRegex: ptr(`(?=.*web)`)},
+		},
+		{
+			name:  "backreferences are left to the server",
+			rules: RSSRules{DontRegex: ptr(`(\w+)\.\1`)},
+		},
+		{
+			name:    "unbalanced parenthesis",
+			rules:   RSSRules{DoRegex: ptr(`(1080p`)},
+			wantErr: true,
+		},
+		{
+			name:    "unbalanced bracket",
+			rules:   RSSRules{DontRegex: ptr(`[a-`)},
+			wantErr: true,
+		},
+		{
+			name:    "zero scan interval",
+			rules:   RSSRules{ScanInterval: interval(0)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			rules:   RSSRules{RSSType: rssType("magnet")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, torboxerrors.ErrInvalidRSSRule) {
+				t.Errorf("Validate() error = %v, expected ErrInvalidRSSRule", err)
+			}
+		})
+	}
+}
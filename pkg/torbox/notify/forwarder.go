@@ -0,0 +1,379 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/library"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DefaultTitleTemplate = "{{.Title}}"
+	DefaultBodyTemplate  = "{{.Message}}"
+)
+
+// Event is what message templates are rendered from. Notification is set for
+// events from the notification stream and Download for finished downloads.
+type Event struct {
+	Type    constants.NotificationType
+	Title   string
+	Message string
+	Time    time.Time
+
+	Notification *models.Notification
+	Download     library.Download
+}
+
+func NotificationEvent(n models.Notification) Event {
+	return Event{
+		Type:         n.Type,
+		Title:        n.Title,
+		Message:      n.Message,
+		Time:         n.CreatedAt.Time,
+		Notification: &n,
+	}
+}
+
+func DownloadEvent(d library.Download, at time.Time) Event {
+	return Event{
+		Type:     constants.NotificationDownloadReady,
+		Title:    "Download complete",
+		Message:  fmt.Sprintf("%s %s has finished downloading", d.Kind(), d.Name()),
+		Time:     at,
+		Download: d,
+	}
+}
+
+// DeadLetter is a message a sink still failed to deliver after every retry.
+type DeadLetter struct {
+	Sink     string    `json:"sink"`
+	Message  Message   `json:"message"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// Forwarder renders notifications and finished downloads into messages and
+// delivers them to every sink, retrying failures before dead-lettering them.
+type Forwarder struct {
+	general *general.GeneralService
+	sinks   []Sink
+
+	titleTemplate  string
+	bodyTemplate   string
+	title          *template.Template
+	body           *template.Template
+	attempts       int
+	backoff        time.Duration
+	deadLetterPath string
+	watchDownloads bool
+	watchInterval  time.Duration
+	streamOptions  []Option
+
+	deadLetterMu sync.Mutex
+	now          func() time.Time
+	sleep        func(ctx context.Context, d time.Duration) error
+}
+
+type ForwarderOption func(*Forwarder)
+
+// WithTemplates sets the text/template sources used for message titles and
+// bodies. Both are rendered from an Event.
+func WithTemplates(title string, body string) ForwarderOption {
+	return func(f *Forwarder) {
+		f.titleTemplate = title
+		f.bodyTemplate = body
+	}
+}
+
+// WithRetry sets how many times a sink is tried per message, waiting backoff
+// before the second attempt and doubling it after each failure.
+func WithRetry(attempts int, backoff time.Duration) ForwarderOption {
+	return func(f *Forwarder) {
+		f.attempts = attempts
+		f.backoff = backoff
+	}
+}
+
+// WithDeadLetterFile appends messages that could not be delivered to path as
+// JSON lines.
+func WithDeadLetterFile(path string) ForwarderOption {
+	return func(f *Forwarder) {
+		f.deadLetterPath = path
+	}
+}
+
+// WithDownloadEvents turns forwarding of finished downloads from the library
+// watcher on or off. It is off by default because TorBox already sends a
+// download_ready notification for each finished download, enable it only when
+// those notifications are turned off in the account settings.
+func WithDownloadEvents(enabled bool, interval time.Duration) ForwarderOption {
+	return func(f *Forwarder) {
+		f.watchDownloads = enabled
+		f.watchInterval = interval
+	}
+}
+
+// WithStreamOptions configures the notification stream Run consumes.
+func WithStreamOptions(opts ...Option) ForwarderOption {
+	return func(f *Forwarder) {
+		f.streamOptions = append(f.streamOptions, opts...)
+	}
+}
+
+func NewForwarder(generalService *general.GeneralService, sinks []Sink, opts ...ForwarderOption) (*Forwarder, error) {
+	f := &Forwarder{
+		general: generalService,
+		sinks:   sinks,
+
+		titleTemplate: DefaultTitleTemplate,
+		bodyTemplate:  DefaultBodyTemplate,
+		attempts:      3,
+		backoff:       time.Second,
+		watchInterval: DefaultInterval,
+		now:           time.Now,
+		sleep:         sleep,
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	var err error
+	f.title, err = template.New("title").Parse(f.titleTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid title template: %w", err)
+	}
+
+	f.body, err = template.New("body").Parse(f.bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid body template: %w", err)
+	}
+
+	return f, nil
+}
+
+// Run forwards new notifications and, when enabled, finished downloads until
+// ctx is done.
+func (f *Forwarder) Run(ctx context.Context) error {
+	stream := New(f.general, f.streamOptions...)
+	stream.Subscribe(func(n models.Notification) {
+		err := f.Forward(ctx, NotificationEvent(n))
+		if err != nil {
+			log.Error().Err(err).Int64("notification_id", n.ID).Msg("failed to forward notification")
+		}
+	})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		stream.Run(ctx)
+	}()
+
+	if f.watchDownloads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			library.New(f.general).Watch(ctx, f.watchInterval, f.forwardCompleted(ctx))
+		}()
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// forwardCompleted forwards downloads that moved into a complete state. The
+// watcher's first poll reports everything as added, which is ignored so a
+// restart does not announce the whole library.
+func (f *Forwarder) forwardCompleted(ctx context.Context) library.WatchFunc {
+	return func(_ []library.Download, events []library.Event) error {
+		for _, e := range events {
+			if e.Type != library.EventStateChanged || e.Previous.IsComplete() || !e.Download.State().IsComplete() {
+				continue
+			}
+
+			err := f.Forward(ctx, DownloadEvent(e.Download, f.now()))
+			if err != nil {
+				log.Error().Err(err).Str("name", e.Download.Name()).Msg("failed to forward download event")
+			}
+		}
+
+		return nil
+	}
+}
+
+// Forward renders e and delivers it to every sink. Messages a sink still
+// fails to take after all attempts are dead-lettered, and the failures are
+// returned together.
+func (f *Forwarder) Forward(ctx context.Context, e Event) error {
+	msg, err := f.Render(e)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sink := range f.sinks {
+		err := f.deliver(ctx, sink, msg)
+		if err == nil {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+
+		deadErr := f.deadLetter(DeadLetter{
+			Sink:     sink.Name(),
+			Message:  msg,
+			Error:    err.Error(),
+			FailedAt: f.now(),
+		})
+		if deadErr != nil {
+			errs = append(errs, deadErr)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Render builds the message for e from the configured templates.
+func (f *Forwarder) Render(e Event) (Message, error) {
+	var title bytes.Buffer
+	err := f.title.Execute(&title, e)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to render title: %w", err)
+	}
+
+	var body bytes.Buffer
+	err = f.body.Execute(&body, e)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to render body: %w", err)
+	}
+
+	return Message{
+		Type:  e.Type,
+		Title: strings.TrimSpace(title.String()),
+		Body:  strings.TrimSpace(body.String()),
+		Time:  e.Time,
+	}, nil
+}
+
+func (f *Forwarder) deliver(ctx context.Context, sink Sink, msg Message) error {
+	var err error
+	delay := f.backoff
+
+	for attempt := 0; attempt < max(f.attempts, 1); attempt++ {
+		if attempt > 0 {
+			log.Debug().
+				Str("sink", sink.Name()).
+				Int("attempt", attempt).
+				Dur("delay", delay).
+				Msg("retrying notification delivery after delay")
+
+			sleepErr := f.sleep(ctx, delay)
+			if sleepErr != nil {
+				return errors.Join(err, sleepErr)
+			}
+
+			delay *= 2
+		}
+
+		err = sink.Send(ctx, msg)
+		if err == nil || isPermanent(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (f *Forwarder) deadLetter(letter DeadLetter) error {
+	log.Warn().
+		Str("sink", letter.Sink).
+		Str("title", letter.Message.Title).
+		Str("error", letter.Error).
+		Msg("dead-lettering notification")
+
+	if f.deadLetterPath == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	f.deadLetterMu.Lock()
+	defer f.deadLetterMu.Unlock()
+
+	file, err := os.OpenFile(f.deadLetterPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+
+	return err
+}
+
+// ReadDeadLetters reads the messages recorded by WithDeadLetterFile. A missing
+// file means nothing has failed.
+func ReadDeadLetters(path string) ([]DeadLetter, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var letters []DeadLetter
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var letter DeadLetter
+		err = json.Unmarshal(line, &letter)
+		if err != nil {
+			return letters, fmt.Errorf("failed to read dead letter: %w", err)
+		}
+
+		letters = append(letters, letter)
+	}
+
+	return letters, scanner.Err()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
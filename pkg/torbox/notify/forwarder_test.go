@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+type fakeSink struct {
+	name     string
+	failures []error
+	sent     []Message
+	attempts int
+}
+
+func (s *fakeSink) Name() string { return s.name }
+
+func (s *fakeSink) Send(ctx context.Context, msg Message) error {
+	s.attempts++
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return err
+	}
+
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestForwarderForward(t *testing.T) {
+	flaky := &fakeSink{name: "flaky", failures: []error{errors.New("timeout")}}
+	down := &fakeSink{name: "down", failures: []error{errors.New("a"), errors.New("b"), errors.New("c")}}
+	rejected := &fakeSink{name: "rejected", failures: []error{&permanentError{err: errors.New("bad request")}}}
+
+	deadLetterPath := filepath.Join(t.TempDir(), "dead.jsonl")
+
+	f, err := NewForwarder(nil, []Sink{flaky, down, rejected},
+		WithTemplates("[{{.Type}}] {{.Title}}", "{{.Message}}{{with .Notification}} (#{{.ID}}){{end}}"),
+		WithRetry(3, time.Second),
+		WithDeadLetterFile(deadLetterPath),
+	)
+	if err != nil {
+		t.Fatalf("NewForwarder() error = %v", err)
+	}
+
+	var delays []time.Duration
+	f.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	event := NotificationEvent(models.Notification{ID: 7, Type: "download_ready", Title: "Ready", Message: "ubuntu.iso"})
+
+	err = f.Forward(context.Background(), event)
+	if err == nil {
+		t.Fatal("expected an error from the failing sinks")
+	}
+
+	if len(flaky.sent) != 1 || flaky.sent[0].Title != "[download_ready] Ready" || flaky.sent[0].Body != "ubuntu.iso (#7)" {
+		t.Errorf("flaky sink sent %+v", flaky.sent)
+	}
+
+	if down.attempts != 3 || rejected.attempts != 1 {
+		t.Errorf("attempts = %d and %d, expected 3 retries and no retry of a permanent error", down.attempts, rejected.attempts)
+	}
+
+	// one retry for flaky, two for down, doubling each time
+	expectedDelays := []time.Duration{time.Second, time.Second, 2 * time.Second}
+	if len(delays) != len(expectedDelays) {
+		t.Fatalf("delays = %v, want %v", delays, expectedDelays)
+	}
+
+	for i := range delays {
+		if delays[i] != expectedDelays[i] {
+			t.Errorf("delays = %v, want %v", delays, expectedDelays)
+		}
+	}
+
+	letters, err := ReadDeadLetters(deadLetterPath)
+	if err != nil {
+		t.Fatalf("ReadDeadLetters() error = %v", err)
+	}
+
+	if len(letters) != 2 || letters[0].Sink != "down" || letters[1].Sink != "rejected" || letters[0].Error != "c" {
+		t.Errorf("unexpected dead letters %+v", letters)
+	}
+}
+
+func TestNewForwarderInvalidTemplate(t *testing.T) {
+	_, err := NewForwarder(nil, nil, WithTemplates("{{.Title", DefaultBodyTemplate))
+	if err == nil {
+		t.Error("expected an error for an unterminated template")
+	}
+}
@@ -0,0 +1,375 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/internal/crypto"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a webhook body, prefixed
+// with "sha256=".
+const SignatureHeader = "X-TorBox-Signature"
+
+// DefaultSinkTimeout bounds a single delivery, so one stalled endpoint cannot
+// hold up the stream that feeds every sink.
+const DefaultSinkTimeout = 30 * time.Second
+
+var defaultClient = &http.Client{Timeout: DefaultSinkTimeout}
+
+// Message is a rendered event, ready to be delivered by a sink.
+type Message struct {
+	Type  constants.NotificationType `json:"type"`
+	Title string                     `json:"title"`
+	Body  string                     `json:"body"`
+	Time  time.Time                  `json:"time"`
+}
+
+// Sink delivers messages to one destination.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, msg Message) error
+}
+
+// permanentError marks a failure that retrying will not fix, such as a
+// rejected payload.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func isPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// WebhookSink posts the message as JSON. When Secret is set the body is
+// signed so the receiver can verify it came from us.
+type WebhookSink struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func NewWebhookSink(url string, secret string) *WebhookSink {
+	return &WebhookSink{
+		URL:    url,
+		Secret: secret,
+		Client: defaultClient,
+	}
+}
+
+func (s *WebhookSink) Name() string { return "webhook" }
+
+func (s *WebhookSink) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if s.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+crypto.ToHMACSHA256([]byte(s.Secret), body))
+	}
+
+	return post(s.Client, req)
+}
+
+// DiscordSink posts the message as an embed to a Discord webhook.
+type DiscordSink struct {
+	WebhookURL string
+	Username   string
+	Client     *http.Client
+}
+
+func NewDiscordSink(webhookURL string) *DiscordSink {
+	return &DiscordSink{
+		WebhookURL: webhookURL,
+		Username:   "TorBox",
+		Client:     defaultClient,
+	}
+}
+
+func (s *DiscordSink) Name() string { return "discord" }
+
+type discordPayload struct {
+	Username string         `json:"username,omitempty"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Timestamp   string `json:"timestamp,omitempty"`
+	Color       int    `json:"color"`
+}
+
+func (s *DiscordSink) Send(ctx context.Context, msg Message) error {
+	embed := discordEmbed{
+		Title:       msg.Title,
+		Description: msg.Body,
+		Color:       discordColor(msg.Type),
+	}
+
+	if !msg.Time.IsZero() {
+		embed.Timestamp = msg.Time.UTC().Format(time.RFC3339)
+	}
+
+	body, err := json.Marshal(discordPayload{
+		Username: s.Username,
+		Embeds:   []discordEmbed{embed},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return post(s.Client, req)
+}
+
+func discordColor(notificationType constants.NotificationType) int {
+	switch notificationType {
+	case constants.NotificationDownloadReady:
+		return 0x2ecc71
+	case constants.NotificationDownloadFailed:
+		return 0xe74c3c
+	default:
+		return 0x3498db
+	}
+}
+
+// NtfySink publishes the message to an ntfy topic.
+type NtfySink struct {
+	Server string
+	Topic  string
+	Token  string
+	Client *http.Client
+}
+
+func NewNtfySink(server string, topic string) *NtfySink {
+	return &NtfySink{
+		Server: server,
+		Topic:  topic,
+		Client: defaultClient,
+	}
+}
+
+func (s *NtfySink) Name() string { return "ntfy" }
+
+func (s *NtfySink) Send(ctx context.Context, msg Message) error {
+	topicURL := strings.TrimRight(s.Server, "/") + "/" + s.Topic
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, topicURL, strings.NewReader(msg.Body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Title", headerValue(msg.Title))
+	if msg.Type != "" {
+		req.Header.Set("Tags", string(msg.Type))
+	}
+
+	if msg.Type == constants.NotificationDownloadFailed {
+		req.Header.Set("Priority", "high")
+	}
+
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	return post(s.Client, req)
+}
+
+// SMTPSink emails the message as plain text. Timeout bounds the whole
+// exchange with the server, on top of any deadline on the context.
+type SMTPSink struct {
+	Addr    string
+	From    string
+	To      []string
+	Auth    smtp.Auth
+	Timeout time.Duration
+}
+
+func NewSMTPSink(addr string, from string, to []string, auth smtp.Auth) *SMTPSink {
+	return &SMTPSink{
+		Addr:    addr,
+		From:    from,
+		To:      to,
+		Auth:    auth,
+		Timeout: DefaultSinkTimeout,
+	}
+}
+
+func (s *SMTPSink) Name() string { return "smtp" }
+
+func (s *SMTPSink) Send(ctx context.Context, msg Message) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	date := msg.Time
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", s.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", headerValue(msg.Title))
+	fmt.Fprintf(&body, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	body.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	body.WriteString("\r\n")
+
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	return s.sendMail(ctx, body.Bytes())
+}
+
+// sendMail follows smtp.SendMail, but dials with ctx and closes the
+// connection when ctx is done so a silent server cannot block the send.
+func (s *SMTPSink) sendMail(ctx context.Context, data []byte) error {
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return errors.Join(err, ctx.Err())
+	}
+	defer client.Close()
+
+	err = s.converse(client, host, data)
+	if err != nil {
+		return errors.Join(err, ctx.Err())
+	}
+
+	return nil
+}
+
+func (s *SMTPSink) converse(client *smtp.Client, host string, data []byte) error {
+	ok, _ := client.Extension("STARTTLS")
+	if ok {
+		err := client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Auth != nil {
+		ok, _ := client.Extension("AUTH")
+		if !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+
+		err := client.Auth(s.Auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := client.Mail(s.From)
+	if err != nil {
+		return err
+	}
+
+	for _, to := range s.To {
+		err = client.Rcpt(to)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// headerValue keeps a title from breaking out of its header line.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
+
+// post sends req and treats any non-2xx status as a failure. Client errors
+// other than rate limiting are permanent.
+func post(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = defaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("%s returned %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(detail)))
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return &permanentError{err: err}
+	}
+
+	return err
+}
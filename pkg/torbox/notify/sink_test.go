@@ -0,0 +1,258 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/internal/crypto"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+)
+
+var testMessage = Message{
+	Type:  constants.NotificationDownloadFailed,
+	Title: "Download failed",
+	Body:  "ubuntu.iso could not be downloaded",
+	Time:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+}
+
+type capturedRequest struct {
+	header http.Header
+	path   string
+	body   []byte
+}
+
+func newCaptureServer(t *testing.T, status int) (*httptest.Server, <-chan capturedRequest) {
+	t.Helper()
+
+	requests := make(chan capturedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- capturedRequest{header: r.Header, path: r.URL.Path, body: body}
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestHTTPSinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		sink  func(url string) Sink
+		check func(t *testing.T, req capturedRequest)
+	}{
+		{
+			name: "webhook",
+			sink: func(url string) Sink { return NewWebhookSink(url, "secret") },
+			check: func(t *testing.T, req capturedRequest) {
+				expected := "sha256=" + crypto.ToHMACSHA256([]byte("secret"), req.body)
+				if got := req.header.Get(SignatureHeader); got != expected {
+					t.Errorf("signature = %q, want %q", got, expected)
+				}
+
+				var msg Message
+				err := json.Unmarshal(req.body, &msg)
+				if err != nil || msg.Title != testMessage.Title {
+					t.Errorf("unexpected body %s (%v)", req.body, err)
+				}
+			},
+		},
+		{
+			name: "discord",
+			sink: func(url string) Sink { return NewDiscordSink(url) },
+			check: func(t *testing.T, req capturedRequest) {
+				var payload discordPayload
+				err := json.Unmarshal(req.body, &payload)
+				if err != nil || len(payload.Embeds) != 1 {
+					t.Fatalf("unexpected body %s (%v)", req.body, err)
+				}
+
+				embed := payload.Embeds[0]
+				if embed.Title != testMessage.Title || embed.Description != testMessage.Body || embed.Color != 0xe74c3c {
+					t.Errorf("unexpected embed %+v", embed)
+				}
+			},
+		},
+		{
+			name: "ntfy",
+			sink: func(url string) Sink {
+				sink := NewNtfySink(url, "torbox")
+				sink.Token = "tk_test"
+				return sink
+			},
+			check: func(t *testing.T, req capturedRequest) {
+				if req.path != "/torbox" || string(req.body) != testMessage.Body {
+					t.Errorf("unexpected request %s %s", req.path, req.body)
+				}
+
+				if req.header.Get("Title") != testMessage.Title || req.header.Get("Priority") != "high" {
+					t.Errorf("unexpected headers %v", req.header)
+				}
+
+				if req.header.Get("Authorization") != "Bearer tk_test" {
+					t.Errorf("missing token, got %q", req.header.Get("Authorization"))
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newCaptureServer(t, http.StatusNoContent)
+
+			err := tt.sink(server.URL).Send(context.Background(), testMessage)
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+
+			tt.check(t, <-requests)
+		})
+	}
+}
+
+func TestHTTPSinkStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		permanent bool
+	}{
+		{status: http.StatusBadRequest, permanent: true},
+		{status: http.StatusTooManyRequests, permanent: false},
+		{status: http.StatusBadGateway, permanent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server, _ := newCaptureServer(t, tt.status)
+
+			err := NewWebhookSink(server.URL, "").Send(context.Background(), testMessage)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			if isPermanent(err) != tt.permanent {
+				t.Errorf("isPermanent() = %v, want %v", isPermanent(err), tt.permanent)
+			}
+		})
+	}
+}
+
+// serveSMTP accepts a single session and returns the DATA it received.
+func serveSMTP(t *testing.T, listener net.Listener) <-chan string {
+	t.Helper()
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+
+		var data strings.Builder
+		inData := false
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			if inData {
+				if line == ".\r\n" {
+					inData = false
+					received <- data.String()
+					reply("250 OK")
+					continue
+				}
+
+				data.WriteString(line)
+				continue
+			}
+
+			command := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(command, "DATA"):
+				inData = true
+				reply("354 go ahead")
+			case strings.HasPrefix(command, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return received
+}
+
+func TestSMTPSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	received := serveSMTP(t, listener)
+
+	sink := NewSMTPSink(listener.Addr().String(), "torbox@example.com", []string{"team@example.com"}, nil)
+
+	err = sink.Send(context.Background(), testMessage)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	data := <-received
+	if !strings.Contains(data, "Subject: Download failed\r\n") {
+		t.Errorf("missing subject in %q", data)
+	}
+
+	if !strings.Contains(data, testMessage.Body) {
+		t.Errorf("missing body in %q", data)
+	}
+}
+
+func TestSMTPSinkTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	// accept the connection but never send the greeting
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			io.Copy(io.Discard, conn)
+		}
+	}()
+
+	sink := NewSMTPSink(listener.Addr().String(), "torbox@example.com", []string{"team@example.com"}, nil)
+	sink.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	err = sink.Send(context.Background(), testMessage)
+	if err == nil {
+		t.Fatal("Send() expected an error from a silent server")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Send() took %s, expected it to give up after the timeout", elapsed)
+	}
+}
@@ -0,0 +1,244 @@
+package notify
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultInterval is how often Run polls unless WithInterval is given.
+const DefaultInterval = time.Minute
+
+// Handler receives each new notification once, oldest first. Handlers run
+// while the stream is polling and must not call back into it.
+type Handler func(n models.Notification)
+
+// Cursor is the stream's position, persisted between runs. Notification IDs
+// only grow, so anything at or below LastID has already been delivered.
+type Cursor struct {
+	LastID    int64     `json:"last_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NotificationStream polls the account's notifications and hands every one it
+// has not delivered before to its subscribers.
+type NotificationStream struct {
+	general *general.GeneralService
+
+	interval  time.Duration
+	statePath string
+	backfill  bool
+
+	mu          sync.Mutex
+	cursor      Cursor
+	loaded      bool
+	started     bool
+	subscribers map[int]Handler
+	nextID      int
+	now         func() time.Time
+}
+
+type Option func(*NotificationStream)
+
+// WithInterval sets how often Run polls for new notifications.
+func WithInterval(interval time.Duration) Option {
+	return func(s *NotificationStream) {
+		s.interval = interval
+	}
+}
+
+// WithStateFile persists the cursor so a restarted stream does not deliver
+// notifications again.
+func WithStateFile(path string) Option {
+	return func(s *NotificationStream) {
+		s.statePath = path
+	}
+}
+
+// WithBackfill delivers notifications that already exist when the stream
+// starts without a saved cursor. By default those are skipped and only
+// notifications that arrive later are delivered.
+func WithBackfill(backfill bool) Option {
+	return func(s *NotificationStream) {
+		s.backfill = backfill
+	}
+}
+
+func New(generalService *general.GeneralService, opts ...Option) *NotificationStream {
+	s := &NotificationStream{
+		general: generalService,
+
+		interval:    DefaultInterval,
+		subscribers: make(map[int]Handler),
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Subscribe registers fn for new notifications and returns a function that
+// removes it again.
+func (s *NotificationStream) Subscribe(fn Handler) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.subscribers, id)
+	}
+}
+
+// Cursor returns the current position of the stream.
+func (s *NotificationStream) Cursor() (Cursor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	return s.cursor, nil
+}
+
+// Run polls every interval until ctx is done. Polls that fail are logged and
+// retried on the next tick.
+func (s *NotificationStream) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := s.Poll()
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to poll notifications")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches notifications once, delivers the new ones to every subscriber
+// and advances the cursor past them.
+func (s *NotificationStream) Poll() ([]models.Notification, error) {
+	notifications, err := s.general.GetNotifications()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := s.fresh(notifications)
+	s.started = true
+
+	for _, n := range fresh {
+		for _, fn := range s.subscribers {
+			fn(n)
+		}
+	}
+
+	if len(fresh) == 0 && !s.cursor.UpdatedAt.IsZero() {
+		return nil, nil
+	}
+
+	for _, n := range notifications {
+		s.cursor.LastID = max(s.cursor.LastID, n.ID)
+	}
+	s.cursor.UpdatedAt = s.now()
+
+	err = s.save()
+	if err != nil {
+		return fresh, err
+	}
+
+	return fresh, nil
+}
+
+// fresh returns the notifications past the cursor, oldest first. The first
+// poll without a saved cursor only sets the cursor unless backfill is on.
+func (s *NotificationStream) fresh(notifications []models.Notification) []models.Notification {
+	if !s.started && s.cursor.UpdatedAt.IsZero() && !s.backfill {
+		return nil
+	}
+
+	var fresh []models.Notification
+	for _, n := range notifications {
+		if n.ID > s.cursor.LastID {
+			fresh = append(fresh, n)
+		}
+	}
+
+	slices.SortFunc(fresh, func(a, b models.Notification) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return fresh
+}
+
+func (s *NotificationStream) load() error {
+	if s.loaded || s.statePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.loaded = true
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, &s.cursor)
+	if err != nil {
+		return fmt.Errorf("failed to read notification cursor: %w", err)
+	}
+
+	s.loaded = true
+
+	return nil
+}
+
+func (s *NotificationStream) save() error {
+	if s.statePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.cursor, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.statePath, data, 0o644)
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestNotificationStreamPoll(t *testing.T) {
+	var body atomic.Value
+	body.Store(`{"success":true,"data":[{"id":1,"type":"download_ready","title":"old"}]}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body.Load().(string)))
+	}))
+	defer server.Close()
+
+	generalService := general.New(http.Client{}, "token")
+	generalService.BaseURL = server.URL
+
+	statePath := filepath.Join(t.TempDir(), "notifications.json")
+
+	var delivered []int64
+	stream := New(generalService, WithStateFile(statePath))
+	stream.Subscribe(func(n models.Notification) {
+		delivered = append(delivered, n.ID)
+	})
+
+	// existing notifications only set the cursor on the first poll
+	_, err := stream.Poll()
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	if len(delivered) != 0 {
+		t.Fatalf("delivered %v on first poll, expected nothing", delivered)
+	}
+
+	body.Store(`{"success":true,"data":[
+		{"id":3,"type":"download_failed","title":"newer"},
+		{"id":2,"type":"download_ready","title":"new"},
+		{"id":1,"type":"download_ready","title":"old"}
+	]}`)
+
+	for range 2 {
+		_, err = stream.Poll()
+		if err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+	}
+
+	if len(delivered) != 2 || delivered[0] != 2 || delivered[1] != 3 {
+		t.Fatalf("delivered = %v, expected [2 3] once each", delivered)
+	}
+
+	// a restarted stream picks up from the saved cursor
+	restarted := New(generalService, WithStateFile(statePath), WithBackfill(true))
+	unsubscribe := restarted.Subscribe(func(n models.Notification) {
+		t.Errorf("redelivered notification %d", n.ID)
+	})
+	defer unsubscribe()
+
+	fresh, err := restarted.Poll()
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+
+	if len(fresh) != 0 {
+		t.Errorf("restarted stream returned %d notifications, expected none", len(fresh))
+	}
+
+	cursor, err := restarted.Cursor()
+	if err != nil {
+		t.Fatalf("Cursor() error = %v", err)
+	}
+
+	if cursor.LastID != 3 {
+		t.Errorf("cursor = %d, expected 3", cursor.LastID)
+	}
+}
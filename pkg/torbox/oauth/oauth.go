@@ -0,0 +1,316 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/rs/zerolog/log"
+)
+
+// Providers only redirect to URIs registered exactly, port included, so the
+// default callback is fixed: register http://127.0.0.1:8085/callback with the
+// provider, or pick another address with WithListenAddr and register that.
+const (
+	DefaultListenAddr   = "127.0.0.1:8085"
+	DefaultCallbackPath = "/callback"
+)
+
+// Helper runs the OAuth consent flow for a cloud integration on a loopback
+// server, so the authorization code never has to be copied by hand.
+type Helper struct {
+	general *general.GeneralService
+
+	info         constants.IntegrationProviderInfo
+	clientID     string
+	authURL      string
+	scopes       []string
+	params       url.Values
+	listenAddr   string
+	callbackPath string
+	openBrowser  func(authURL string) error
+}
+
+type Option func(*Helper)
+
+// WithAuthURL overrides the provider's authorization endpoint from the registry.
+func WithAuthURL(authURL string) Option {
+	return func(h *Helper) {
+		h.authURL = authURL
+	}
+}
+
+// WithScopes overrides the scopes requested from the provider.
+func WithScopes(scopes ...string) Option {
+	return func(h *Helper) {
+		h.scopes = scopes
+	}
+}
+
+// WithParam adds a provider specific query parameter to the authorization URL,
+// such as access_type=offline for Google.
+func WithParam(key string, value string) Option {
+	return func(h *Helper) {
+		h.params.Add(key, value)
+	}
+}
+
+// WithListenAddr sets the loopback address the callback server listens on.
+// The address must match a redirect URI registered with the provider.
+func WithListenAddr(addr string) Option {
+	return func(h *Helper) {
+		h.listenAddr = addr
+	}
+}
+
+func WithCallbackPath(path string) Option {
+	return func(h *Helper) {
+		h.callbackPath = path
+	}
+}
+
+// WithBrowser sets how the authorization URL is shown to the user. By default
+// it is logged.
+func WithBrowser(open func(authURL string) error) Option {
+	return func(h *Helper) {
+		h.openBrowser = open
+	}
+}
+
+func New(generalService *general.GeneralService, provider constants.IntegrationProvider, clientID string, opts ...Option) (*Helper, error) {
+	info, ok := provider.Info()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", torboxerrors.ErrUnknownIntegration, provider)
+	}
+
+	if info.Auth != constants.IntegrationAuthOAuth {
+		return nil, fmt.Errorf("%s is authorized with an API key, not OAuth", info.Name)
+	}
+
+	h := &Helper{
+		general: generalService,
+
+		info:         info,
+		clientID:     clientID,
+		authURL:      info.OAuthURL,
+		scopes:       info.OAuthScopes,
+		params:       url.Values{},
+		listenAddr:   DefaultListenAddr,
+		callbackPath: DefaultCallbackPath,
+		openBrowser:  logURL,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// Authorize runs the whole flow: it starts a session, shows the user the
+// consent page, waits for the redirect and links the provider with the code.
+func (h *Helper) Authorize(ctx context.Context) error {
+	session, err := h.Begin()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	err = h.openBrowser(session.AuthURL())
+	if err != nil {
+		return err
+	}
+
+	code, err := session.Wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	return h.general.AuthorizeWithPKCE(h.info.Provider, code, session.Verifier(), session.RedirectURI())
+}
+
+// Begin starts the callback server and prepares a fresh state and PKCE
+// verifier. The session must be closed once done with.
+func (h *Helper) Begin() (*Session, error) {
+	state, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := randomString(64)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", h.listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+
+	s := &Session{
+		state:       state,
+		verifier:    verifier,
+		redirectURI: "http://" + listener.Addr().String() + h.callbackPath,
+		results:     make(chan result, 1),
+	}
+
+	s.authURL, err = h.buildAuthURL(s)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(h.callbackPath, s.callback)
+
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go s.server.Serve(listener)
+
+	return s, nil
+}
+
+func (h *Helper) buildAuthURL(s *Session) (string, error) {
+	authURL, err := url.Parse(h.authURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization url: %w", err)
+	}
+
+	query := authURL.Query()
+	for key, values := range h.params {
+		query[key] = values
+	}
+
+	query.Set("response_type", "code")
+	query.Set("client_id", h.clientID)
+	query.Set("redirect_uri", s.redirectURI)
+	query.Set("state", s.state)
+	query.Set("code_challenge", challenge(s.verifier))
+	query.Set("code_challenge_method", "S256")
+
+	if len(h.scopes) > 0 {
+		query.Set("scope", strings.Join(h.scopes, " "))
+	}
+
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+type result struct {
+	code string
+	err  error
+}
+
+// Session is one pending authorization. Only the first redirect carrying a
+// valid state is accepted.
+type Session struct {
+	authURL     string
+	redirectURI string
+	state       string
+	verifier    string
+
+	server  *http.Server
+	results chan result
+	once    sync.Once
+}
+
+func (s *Session) AuthURL() string     { return s.authURL }
+func (s *Session) RedirectURI() string { return s.redirectURI }
+
+// Verifier is the PKCE code verifier whose challenge was sent in AuthURL.
+func (s *Session) Verifier() string { return s.verifier }
+
+// Wait blocks until the provider redirects back or ctx is done.
+func (s *Session) Wait(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-s.results:
+		return r.code, r.err
+	}
+}
+
+func (s *Session) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Session) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// a mismatched state may be a forged request, so it is rejected without
+	// ending the session
+	if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(s.state)) != 1 {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		log.Warn().Msg("ignoring oauth callback with mismatched state")
+		return
+	}
+
+	var res result
+	switch {
+	case query.Get("error") != "":
+		res.err = fmt.Errorf("%w: %s %s", torboxerrors.ErrOAuthDenied, query.Get("error"), query.Get("error_description"))
+	case query.Get("code") == "":
+		res.err = fmt.Errorf("%w: no code in callback", torboxerrors.ErrOAuthDenied)
+	default:
+		res.code = query.Get("code")
+	}
+
+	delivered := false
+	s.once.Do(func() {
+		s.results <- res
+		delivered = true
+	})
+
+	if !delivered {
+		http.Error(w, "authorization already completed", http.StatusConflict)
+		return
+	}
+
+	if res.err != nil {
+		http.Error(w, "Authorization failed, you can close this window.", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("Authorization complete, you can close this window."))
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func logURL(authURL string) error {
+	log.Info().Str("url", authURL).Msg("open this url to authorize the integration")
+	return nil
+}
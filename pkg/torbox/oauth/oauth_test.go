@@ -0,0 +1,147 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+func TestAuthorizeEndToEnd(t *testing.T) {
+	// the fake provider approves every request and remembers the challenge
+	// it was given for the code it hands out
+	var challengeSent string
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("client_id") != "client-123" || query.Get("code_challenge_method") != "S256" || query.Get("response_type") != "code" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		challengeSent = query.Get("code_challenge")
+
+		redirect, _ := url.Parse(query.Get("redirect_uri"))
+		redirect.RawQuery = url.Values{"code": {"auth-code"}, "state": {query.Get("state")}}.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	}))
+	defer provider.Close()
+
+	var received models.IntegrationAuthRequest
+	var receivedPath string
+	torbox := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"success":true}`))
+	}))
+	defer torbox.Close()
+
+	generalService := general.New(http.Client{}, "token")
+	generalService.BaseURL = torbox.URL
+
+	helper, err := New(generalService, constants.IntegrationDropbox, "client-123",
+		WithAuthURL(provider.URL+"/oauth2/authorize"),
+		WithListenAddr("127.0.0.1:0"),
+		WithBrowser(func(authURL string) error {
+			resp, err := http.Get(authURL)
+			if err != nil {
+				return err
+			}
+
+			return resp.Body.Close()
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = helper.Authorize(ctx)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+
+	if receivedPath != "/"+constants.PATH_INTEGRATION_DROPBOX || received.Code != "auth-code" {
+		t.Errorf("TorBox received %+v at %s", received, receivedPath)
+	}
+
+	if challenge(received.CodeVerifier) != challengeSent {
+		t.Errorf("verifier %q does not match challenge %q", received.CodeVerifier, challengeSent)
+	}
+}
+
+func TestSessionCallback(t *testing.T) {
+	helper, err := New(nil, constants.IntegrationGoogleDrive, "client-123", WithListenAddr("127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		params  func(state string) url.Values
+		status  int
+		wantErr error
+	}{
+		{
+			name:   "forged state is ignored",
+			params: func(string) url.Values { return url.Values{"code": {"evil"}, "state": {"forged"}} },
+			status: http.StatusBadRequest,
+		},
+		{
+			name:    "denied",
+			params:  func(state string) url.Values { return url.Values{"error": {"access_denied"}, "state": {state}} },
+			status:  http.StatusForbidden,
+			wantErr: torboxerrors.ErrOAuthDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := helper.Begin()
+			if err != nil {
+				t.Fatalf("Begin() error = %v", err)
+			}
+			defer session.Close()
+
+			resp, err := http.Get(session.RedirectURI() + "?" + tt.params(session.state).Encode())
+			if err != nil {
+				t.Fatalf("callback error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err = session.Wait(ctx)
+			expected := tt.wantErr
+			if expected == nil {
+				expected = context.DeadlineExceeded
+			}
+
+			if !errors.Is(err, expected) {
+				t.Errorf("Wait() error = %v, want %v", err, expected)
+			}
+		})
+	}
+}
+
+func TestNewRejectsAPIKeyProviders(t *testing.T) {
+	_, err := New(nil, constants.IntegrationGofile, "client-123")
+	if err == nil {
+		t.Error("expected an error for an API key provider")
+	}
+}
@@ -2,10 +2,16 @@ package torbox
 
 type options struct {
 	apiKey string
+
+	rateLimit      float64
+	rateLimitBurst int
 }
 
 func defaultOptions() options {
-	defaultOptions := options{}
+	defaultOptions := options{
+		rateLimit:      5,
+		rateLimitBurst: 5,
+	}
 
 	return defaultOptions
 }
@@ -17,3 +23,12 @@ func WithAPIKey(i string) Option {
 		o.apiKey = i
 	}
 }
+
+// WithRateLimit caps general API requests per second, shared across all
+// callers of the client. A rate of zero disables the limiter.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit = requestsPerSecond
+		o.rateLimitBurst = burst
+	}
+}
@@ -0,0 +1,22 @@
+package scheduler
+
+import (
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/library"
+)
+
+// Limits caps how many downloads of each kind may be active at once. Kinds
+// without an entry are never started by the scheduler.
+type Limits map[library.Kind]int
+
+// PlanLimits returns the per-kind limits for a plan's active slots. Unknown
+// plans get the free plan's single slot.
+func PlanLimits(plan constants.Plan) Limits {
+	slots := plan.Limits().ActiveSlots
+
+	return Limits{
+		library.KindTorrent:     slots,
+		library.KindUsenet:      slots,
+		library.KindWebDownload: slots,
+	}
+}
@@ -0,0 +1,345 @@
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/library"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Entry is a queued TorBox item waiting for a slot. Kind is the kind it
+// becomes once started.
+type Entry struct {
+	QueuedID int64        `json:"queued_id"`
+	Kind     library.Kind `json:"kind"`
+	Name     string       `json:"name"`
+	Hash     string       `json:"hash"`
+	Priority int          `json:"priority"`
+	AddedAt  time.Time    `json:"added_at"`
+}
+
+// Scheduler starts queued torrents, usenet and web downloads in priority
+// order as active slots free up. TorBox leaves the order of its queue to the
+// client, so the scheduler keeps its own and can persist it between runs.
+type Scheduler struct {
+	general *general.GeneralService
+	library *library.Library
+
+	limits          Limits
+	interval        time.Duration
+	defaultPriority int
+	statePath       string
+
+	mu     sync.Mutex
+	queue  map[int64]*Entry
+	loaded bool
+	now    func() time.Time
+}
+
+// DefaultInterval is how often Run polls unless WithInterval is given.
+const DefaultInterval = time.Minute
+
+type Option func(*Scheduler)
+
+// WithLimits overrides the limits otherwise derived from the account's plan.
+func WithLimits(limits Limits) Option {
+	return func(s *Scheduler) {
+		s.limits = limits
+	}
+}
+
+// WithInterval sets how often Run polls for freed slots.
+func WithInterval(interval time.Duration) Option {
+	return func(s *Scheduler) {
+		s.interval = interval
+	}
+}
+
+// WithDefaultPriority is the priority given to queued items the scheduler
+// discovers on its own.
+func WithDefaultPriority(priority int) Option {
+	return func(s *Scheduler) {
+		s.defaultPriority = priority
+	}
+}
+
+// WithStateFile persists the queue and priorities across restarts.
+func WithStateFile(path string) Option {
+	return func(s *Scheduler) {
+		s.statePath = path
+	}
+}
+
+func New(generalService *general.GeneralService, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		general: generalService,
+		library: library.New(generalService),
+
+		interval: DefaultInterval,
+		queue:    make(map[int64]*Entry),
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// SetPriority sets the priority of a queued item, higher values start first.
+// IDs that are not in the TorBox queue are dropped on the next schedule.
+func (s *Scheduler) SetPriority(queuedId int64, priority int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return err
+	}
+
+	entry, ok := s.queue[queuedId]
+	if !ok {
+		entry = &Entry{
+			QueuedID: queuedId,
+			Kind:     library.KindTorrent,
+			AddedAt:  s.now(),
+		}
+		s.queue[queuedId] = entry
+	}
+
+	entry.Priority = priority
+
+	return s.save()
+}
+
+// Queue returns the tracked entries in the order they will be started.
+func (s *Scheduler) Queue() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ordered(), nil
+}
+
+// Run watches the library and starts queued items whenever a slot frees up,
+// until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) error {
+	err := s.resolveLimits()
+	if err != nil {
+		return err
+	}
+
+	return s.library.Watch(ctx, s.interval, func(downloads []library.Download, events []library.Event) error {
+		_, err := s.schedule(downloads)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to start queued items")
+		}
+
+		return nil
+	})
+}
+
+// RunOnce fills every free slot from the current library and returns the
+// entries that were started.
+func (s *Scheduler) RunOnce() ([]Entry, error) {
+	err := s.resolveLimits()
+	if err != nil {
+		return nil, err
+	}
+
+	downloads, err := s.library.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.schedule(downloads)
+}
+
+func (s *Scheduler) resolveLimits() error {
+	if s.limits != nil {
+		return nil
+	}
+
+	user, err := s.general.GetUser()
+	if err != nil {
+		return fmt.Errorf("failed to get plan for limits: %w", err)
+	}
+
+	s.limits = PlanLimits(user.Plan)
+
+	return nil
+}
+
+func (s *Scheduler) schedule(downloads []library.Download) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[library.Kind]int)
+	for _, d := range downloads {
+		if d.Kind() != library.KindQueued && d.State().IsActive() {
+			active[d.Kind()]++
+		}
+	}
+
+	s.sync(downloads)
+
+	var started []Entry
+	var errs []error
+	for _, entry := range s.ordered() {
+		if active[entry.Kind] >= s.limits[entry.Kind] {
+			continue
+		}
+
+		err := s.general.ControlQueuedTorrent(entry.QueuedID, constants.ControlQueuedOperationStart)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("start %s %d: %w", entry.Kind, entry.QueuedID, err))
+			continue
+		}
+
+		log.Info().
+			Int64("queued_id", entry.QueuedID).
+			Str("kind", string(entry.Kind)).
+			Str("name", entry.Name).
+			Int("priority", entry.Priority).
+			Msg("started queued item")
+
+		active[entry.Kind]++
+		started = append(started, entry)
+		delete(s.queue, entry.QueuedID)
+	}
+
+	err = s.save()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return started, errors.Join(errs...)
+}
+
+// sync adds newly queued items and forgets ones that left the TorBox queue,
+// whether started elsewhere or deleted.
+func (s *Scheduler) sync(downloads []library.Download) {
+	queued := make(map[int64]models.QueuedDownload)
+	for _, d := range downloads {
+		q, ok := d.(interface{ Queued() models.QueuedDownload })
+		if ok {
+			queued[d.ID()] = q.Queued()
+		}
+	}
+
+	for id := range s.queue {
+		if _, ok := queued[id]; !ok {
+			delete(s.queue, id)
+		}
+	}
+
+	for id, q := range queued {
+		entry, ok := s.queue[id]
+		if !ok {
+			addedAt := q.CreatedAt.Time
+			if addedAt.IsZero() {
+				addedAt = s.now()
+			}
+
+			entry = &Entry{
+				QueuedID: id,
+				Priority: s.defaultPriority,
+				AddedAt:  addedAt,
+			}
+			s.queue[id] = entry
+		}
+
+		entry.Kind = queuedKind(q.Type)
+		entry.Name = q.Name
+		entry.Hash = q.Hash
+	}
+}
+
+// ordered sorts by priority, then by the time the item was queued.
+func (s *Scheduler) ordered() []Entry {
+	entries := make([]Entry, 0, len(s.queue))
+	for _, entry := range s.queue {
+		entries = append(entries, *entry)
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(b.Priority, a.Priority),
+			a.AddedAt.Compare(b.AddedAt),
+			cmp.Compare(a.QueuedID, b.QueuedID),
+		)
+	})
+
+	return entries
+}
+
+func queuedKind(queuedType string) library.Kind {
+	switch library.Kind(queuedType) {
+	case library.KindUsenet:
+		return library.KindUsenet
+	case library.KindWebDownload:
+		return library.KindWebDownload
+	default:
+		return library.KindTorrent
+	}
+}
+
+func (s *Scheduler) load() error {
+	if s.loaded || s.statePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.loaded = true
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, &s.queue)
+	if err != nil {
+		return fmt.Errorf("failed to read scheduler state: %w", err)
+	}
+
+	s.loaded = true
+
+	return nil
+}
+
+func (s *Scheduler) save() error {
+	if s.statePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.queue, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.statePath, data, 0o644)
+}
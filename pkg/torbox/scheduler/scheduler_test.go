@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/library"
+)
+
+func newTestServer(t *testing.T, responses map[string]string, started *[]int64) *general.GeneralService {
+	t.Helper()
+
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/queued/controlqueued" {
+			var body struct {
+				QueuedID int64 `json:"queued_id"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			mu.Lock()
+			*started = append(*started, body.QueuedID)
+			mu.Unlock()
+
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			response = `{"success":true,"data":[]}`
+		}
+
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	generalService := general.New(http.Client{}, "token")
+	generalService.BaseURL = server.URL
+
+	return generalService
+}
+
+func TestRunOnce(t *testing.T) {
+	responses := map[string]string{
+		"/api/torrents/mylist": `{"success":true,"data":[{"id":1,"download_state":"downloading"}]}`,
+		"/api/queued/getqueued": `{"success":true,"data":[
+			{"id":10,"type":"torrent","name":"old","created_at":"2024-01-01T00:00:00Z"},
+			{"id":11,"type":"torrent","name":"new","created_at":"2024-01-02T00:00:00Z"},
+			{"id":12,"type":"torrent","name":"urgent","created_at":"2024-01-03T00:00:00Z"},
+			{"id":20,"type":"usenet","name":"nzb","created_at":"2024-01-01T00:00:00Z"}
+		]}`,
+	}
+
+	var started []int64
+	generalService := newTestServer(t, responses, &started)
+	statePath := filepath.Join(t.TempDir(), "scheduler.json")
+
+	s := New(generalService,
+		WithLimits(Limits{library.KindTorrent: 3, library.KindUsenet: 0}),
+		WithStateFile(statePath),
+	)
+
+	_, err := s.RunOnce()
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+
+	if len(started) != 2 || started[0] != 10 || started[1] != 11 {
+		t.Fatalf("started = %v, expected [10 11] in queue order", started)
+	}
+
+	// a fresh scheduler reads the remaining queue back from the state file
+	restored := New(generalService, WithLimits(Limits{}), WithStateFile(statePath))
+
+	err = restored.SetPriority(20, 5)
+	if err != nil {
+		t.Fatalf("SetPriority() error = %v", err)
+	}
+
+	queue, err := restored.Queue()
+	if err != nil {
+		t.Fatalf("Queue() error = %v", err)
+	}
+
+	ids := make([]int64, 0, len(queue))
+	for _, entry := range queue {
+		ids = append(ids, entry.QueuedID)
+	}
+
+	if !slices.Equal(ids, []int64{20, 12}) {
+		t.Errorf("Queue() = %v, expected [20 12]", ids)
+	}
+}
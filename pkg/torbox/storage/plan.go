@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+type Eviction struct {
+	Torrent models.Torrent
+	Score   float64
+	Err     error
+}
+
+type Plan struct {
+	Strategy       Strategy
+	IncomingSize   int64
+	AvailableSpace int64
+	UsedSpace      int64
+
+	Evictions []Eviction
+	Freed     int64
+
+	// Fits is false when evicting every candidate still leaves too little space.
+	Fits bool
+}
+
+func (p *Plan) Print(w io.Writer) {
+	fmt.Fprintf(w, "incoming %d bytes, %d available, %d used\n", p.IncomingSize, p.AvailableSpace, p.UsedSpace)
+
+	if len(p.Evictions) == 0 && p.Fits {
+		fmt.Fprintln(w, "fits without evictions")
+		return
+	}
+
+	fmt.Fprintf(w, "evictions by %s:\n", p.Strategy)
+	for _, eviction := range p.Evictions {
+		status := ""
+		if eviction.Err != nil {
+			status = fmt.Sprintf(" (failed: %v)", eviction.Err)
+		}
+
+		fmt.Fprintf(w, "  delete %d %s, %d bytes, score %.2f%s\n",
+			eviction.Torrent.ID,
+			eviction.Torrent.Name,
+			eviction.Torrent.Size,
+			eviction.Score,
+			status,
+		)
+	}
+
+	if p.Fits {
+		fmt.Fprintf(w, "frees %d bytes, item fits\n", p.Freed)
+	} else {
+		fmt.Fprintf(w, "frees %d bytes, item still does not fit\n", p.Freed)
+	}
+}
@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+	"github.com/dylanmazurek/go-torbox/pkg/torrent"
+	"github.com/rs/zerolog/log"
+)
+
+type Strategy string
+
+const (
+	// StrategyLRU evicts the least recently updated torrents first.
+	StrategyLRU Strategy = "lru"
+	// StrategyAge evicts the oldest torrents first.
+	StrategyAge Strategy = "age"
+	// StrategyRatio evicts the torrents that have seeded the most first.
+	StrategyRatio Strategy = "ratio"
+	// StrategyCustom evicts by the score function set with WithScoreFunc.
+	StrategyCustom Strategy = "custom"
+)
+
+// Planner works out which completed torrents to evict so an incoming item fits
+// in the account's available space.
+type Planner struct {
+	general *general.GeneralService
+
+	strategy Strategy
+	score    func(models.Torrent) float64
+	protect  func(models.Torrent) bool
+	now      func() time.Time
+}
+
+type Option func(*Planner)
+
+func WithStrategy(strategy Strategy) Option {
+	return func(p *Planner) {
+		p.strategy = strategy
+	}
+}
+
+// WithScoreFunc evicts torrents with the highest score first and switches the
+// planner to StrategyCustom.
+func WithScoreFunc(score func(models.Torrent) float64) Option {
+	return func(p *Planner) {
+		p.strategy = StrategyCustom
+		p.score = score
+	}
+}
+
+// WithProtect excludes torrents for which protect returns true from eviction.
+func WithProtect(protect func(models.Torrent) bool) Option {
+	return func(p *Planner) {
+		p.protect = protect
+	}
+}
+
+func New(generalService *general.GeneralService, opts ...Option) *Planner {
+	p := &Planner{
+		general: generalService,
+
+		strategy: StrategyLRU,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Plan compares incomingSize against the available space and lists the
+// evictions needed to make it fit. Nothing is deleted. Items over the plan's
+// approximate size cap are only logged, TorBox decides whether to accept them.
+func (p *Planner) Plan(incomingSize int64) (*Plan, error) {
+	stats, err := p.general.GetStats()
+	if err != nil {
+		return nil, err
+	}
+
+	limits := stats.Plan.Limits()
+	if stats.Plan.Known() && !limits.AllowsSize(incomingSize) {
+		log.Warn().
+			Int64("size", incomingSize).
+			Str("plan", stats.Plan.String()).
+			Int64("max_download_size", limits.MaxDownloadSize).
+			Msg("item may be over the plan's download size limit")
+	}
+
+	plan := &Plan{
+		Strategy:       p.strategy,
+		IncomingSize:   incomingSize,
+		AvailableSpace: stats.AvailableSpace,
+		UsedSpace:      stats.UsedSpace,
+	}
+
+	if incomingSize <= stats.AvailableSpace {
+		plan.Fits = true
+		return plan, nil
+	}
+
+	activeTorrents, err := p.general.GetActiveTorrents()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Eviction
+	for _, t := range activeTorrents {
+		if !t.IsDownloaded() || (p.protect != nil && p.protect(t)) {
+			continue
+		}
+
+		score, err := p.scoreOf(t)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, Eviction{
+			Torrent: t,
+			Score:   score,
+		})
+	}
+
+	slices.SortStableFunc(candidates, func(a, b Eviction) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, candidate := range candidates {
+		if plan.AvailableSpace+plan.Freed >= incomingSize {
+			break
+		}
+
+		plan.Evictions = append(plan.Evictions, candidate)
+		plan.Freed += candidate.Torrent.Size
+	}
+
+	plan.Fits = plan.AvailableSpace+plan.Freed >= incomingSize
+
+	return plan, nil
+}
+
+// Execute deletes every torrent in the plan's eviction list.
+func (p *Planner) Execute(plan *Plan) error {
+	var errs []error
+	for i := range plan.Evictions {
+		eviction := &plan.Evictions[i]
+
+		log.Info().
+			Int64("torrent_id", eviction.Torrent.ID).
+			Str("name", eviction.Torrent.Name).
+			Int64("size", eviction.Torrent.Size).
+			Msg("evicting torrent to free space")
+
+		eviction.Err = p.general.ControlActiveTorrent(eviction.Torrent.ID, constants.ControlActiveOperationDelete)
+		if eviction.Err != nil {
+			errs = append(errs, fmt.Errorf("evict torrent %d: %w", eviction.Torrent.ID, eviction.Err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AddTorrent sizes the request from the cache or the .torrent file, evicts as
+// planned and creates the torrent. With dryRun set only the plan is returned.
+func (p *Planner) AddTorrent(r models.CreateTorrentRequest, dryRun bool) (*Plan, *models.Torrent, error) {
+	size, err := p.sizeOf(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := p.Plan(size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !plan.Fits {
+		return plan, nil, fmt.Errorf("need %d bytes, %d available after evictions: %w", size, plan.AvailableSpace+plan.Freed, torboxerrors.ErrInsufficientSpace)
+	}
+
+	if dryRun {
+		return plan, nil, nil
+	}
+
+	err = p.Execute(plan)
+	if err != nil {
+		return plan, nil, err
+	}
+
+	created, err := p.general.CreateTorrent(r)
+	if err != nil {
+		return plan, nil, err
+	}
+
+	return plan, created, nil
+}
+
+// SizeFromCache looks up the size TorBox has cached for hash.
+func (p *Planner) SizeFromCache(hash string) (int64, error) {
+	cached, err := p.general.CheckCached(hash)
+	if err != nil {
+		return 0, err
+	}
+
+	if cached == nil || cached.Size == 0 {
+		return 0, fmt.Errorf("size of %s is unknown, it is not cached", hash)
+	}
+
+	return cached.Size, nil
+}
+
+// SizeFromTorrentFile sums the file lengths of a .torrent file.
+func SizeFromTorrentFile(data []byte) (int64, error) {
+	parsed, err := torrent.Parse(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, file := range parsed.Files {
+		size += file.Length
+	}
+
+	return size, nil
+}
+
+func (p *Planner) sizeOf(r models.CreateTorrentRequest) (int64, error) {
+	if r.File != nil {
+		return SizeFromTorrentFile(r.File)
+	}
+
+	if r.Magnet != nil {
+		return p.SizeFromCache(r.Magnet.Hash)
+	}
+
+	return 0, errors.New("request has neither a magnet nor a torrent file")
+}
+
+func (p *Planner) scoreOf(t models.Torrent) (float64, error) {
+	now := p.now()
+
+	switch p.strategy {
+	case StrategyLRU:
+		lastUsed := t.UpdatedAt
+		if lastUsed.IsZero() {
+			lastUsed = t.CreatedAt
+		}
+
+		if lastUsed.IsZero() {
+			return 0, nil
+		}
+
+		return now.Sub(lastUsed.Time).Seconds(), nil
+	case StrategyAge:
+		if t.CreatedAt.IsZero() {
+			return 0, nil
+		}
+
+		return now.Sub(t.CreatedAt.Time).Seconds(), nil
+	case StrategyRatio:
+		return t.Ratio, nil
+	case StrategyCustom:
+		if p.score == nil {
+			return 0, errors.New("custom strategy requires a score function")
+		}
+
+		return p.score(t), nil
+	default:
+		return 0, fmt.Errorf("unknown eviction strategy %q", p.strategy)
+	}
+}
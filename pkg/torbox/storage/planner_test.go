@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dylanmazurek/go-torbox/pkg/magnet"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/constants"
+	torboxerrors "github.com/dylanmazurek/go-torbox/pkg/torbox/errors"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/general"
+	"github.com/dylanmazurek/go-torbox/pkg/torbox/models"
+)
+
+const activeTorrents = `{"success":true,"data":[
+	{"id":1,"size":30,"download_finished":true,"updated_at":"2024-01-03T00:00:00Z"},
+	{"id":2,"size":30,"download_finished":true,"updated_at":"2024-01-01T00:00:00Z"},
+	{"id":3,"size":30,"download_finished":false,"updated_at":"2023-01-01T00:00:00Z"}
+]}`
+
+func TestPlannerAddTorrentDryRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		cached    string
+		available int64
+		evictions []int64
+		fits      bool
+		err       error
+		sizeErr   bool
+	}{
+		{
+			name:      "fits without evictions",
+			cached:    `[{"hash":"abc","size":50}]`,
+			available: 100,
+			fits:      true,
+		},
+		{
+			name:      "evicts least recently used first",
+			cached:    `[{"hash":"abc","size":50}]`,
+			available: 30,
+			evictions: []int64{2},
+			fits:      true,
+		},
+		{
+			name:      "unfinished torrents are never evicted",
+			cached:    `[{"hash":"abc","size":200}]`,
+			available: 30,
+			evictions: []int64{2, 1},
+			err:       torboxerrors.ErrInsufficientSpace,
+		},
+		{
+			name:      "over the plan's size cap is left to the API",
+			cached:    `[{"hash":"abc","size":21474836480}]`,
+			available: 1 << 40,
+			fits:      true,
+		},
+		{
+			name:      "uncached magnet has no size",
+			cached:    `[]`,
+			available: 100,
+			sizeErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/" + constants.PATH_TORRENTS_CHECK_CACHED:
+					fmt.Fprintf(w, `{"success":true,"data":%s}`, tt.cached)
+				case "/" + constants.PATH_STATS:
+					fmt.Fprintf(w, `{"success":true,"data":{"available_space":%d,"used_space":60,"plan":0}}`, tt.available)
+				case "/" + constants.PATH_TORRENTS_GET_ACTIVE:
+					w.Write([]byte(activeTorrents))
+				default:
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			service := general.New(http.Client{}, "token")
+			service.BaseURL = server.URL
+
+			planner := New(service)
+			planner.now = func() time.Time { return time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC) }
+
+			plan, _, err := planner.AddTorrent(models.CreateTorrentRequest{Magnet: &magnet.Magnet{Hash: "abc"}}, true)
+			if tt.sizeErr {
+				if err == nil {
+					t.Fatal("expected an error for an uncached magnet")
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+
+			var evicted []int64
+			for _, eviction := range plan.Evictions {
+				evicted = append(evicted, eviction.Torrent.ID)
+			}
+
+			if !slices.Equal(evicted, tt.evictions) {
+				t.Errorf("expected evictions %v, got %v", tt.evictions, evicted)
+			}
+
+			if plan.Fits != tt.fits {
+				t.Errorf("expected fits %v, got %v", tt.fits, plan.Fits)
+			}
+		})
+	}
+}
+
+func TestSizeFromTorrentFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected int64
+	}{
+		{
+			name:     "single file",
+			data:     "d4:infod6:lengthi42e4:name4:test12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaaee",
+			expected: 42,
+		},
+		{
+			name:     "multiple files",
+			data:     "d4:infod5:filesld6:lengthi10e4:pathl1:aeed6:lengthi32e4:pathl1:beee4:name4:test12:piece lengthi16384e6:pieces20:aaaaaaaaaaaaaaaaaaaaee",
+			expected: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := SizeFromTorrentFile([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if size != tt.expected {
+				t.Errorf("expected %d bytes, got %d", tt.expected, size)
+			}
+		})
+	}
+}